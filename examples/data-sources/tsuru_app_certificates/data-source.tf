@@ -0,0 +1,10 @@
+data "tsuru_app_certificates" "my-app" {
+  app = "sample-app"
+}
+
+output "expiring_certificates" {
+  value = [
+    for certificate in data.tsuru_app_certificates.my-app.certificates :
+    certificate.cname if certificate.ready && certificate.days_remaining < 15
+  ]
+}
@@ -0,0 +1,6 @@
+data "tsuru_events" "failed-deploys" {
+  kinds        = ["app.deploy"]
+  target_type  = "app"
+  target_value = "sample-app"
+  status       = "failed"
+}
@@ -0,0 +1,7 @@
+data "tsuru_quota" "my-team" {
+  team = "my-team"
+}
+
+data "tsuru_quota" "my-app" {
+  app = "sample-app"
+}
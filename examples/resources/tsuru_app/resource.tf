@@ -18,5 +18,11 @@ resource "tsuru_app" "my-app" {
     }
   }
 
+  plan_override {
+    memory    = "512Mi"
+    cpu       = "500m"
+    cpu_burst = 1.5
+  }
+
   restart_on_update = true
 }
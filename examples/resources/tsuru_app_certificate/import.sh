@@ -0,0 +1,4 @@
+terraform import tsuru_app_certificate.resource_name "app::cname"
+
+# example
+terraform import tsuru_app_certificate.my-app "sample-app::www.example.com"
@@ -0,0 +1,6 @@
+resource "tsuru_app_certificate" "my-app" {
+  app         = tsuru_app.my-app.name
+  cname       = "www.example.com"
+  certificate = file("${path.module}/certs/www.example.com.crt")
+  private_key = file("${path.module}/certs/www.example.com.key")
+}
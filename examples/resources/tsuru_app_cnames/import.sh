@@ -0,0 +1,4 @@
+terraform import tsuru_app_cnames.resource_name "app"
+
+# example
+terraform import tsuru_app_cnames.my-app "sample-app"
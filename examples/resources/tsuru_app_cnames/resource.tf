@@ -0,0 +1,9 @@
+resource "tsuru_app_cnames" "my-app" {
+  app = tsuru_app.my-app.name
+  cnames = [
+    "www.example.com",
+    "example.com",
+  ]
+  wait_for_router       = true
+  wait_for_certificates = true
+}
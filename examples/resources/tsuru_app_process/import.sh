@@ -0,0 +1,4 @@
+terraform import tsuru_app_process.resource_name "app::process"
+
+# example
+terraform import tsuru_app_process.worker "sample-app::worker"
@@ -0,0 +1,23 @@
+resource "tsuru_app_process" "worker" {
+  app  = tsuru_app.my-app.name
+  name = "worker"
+  plan = "c1m2"
+
+  metadata {
+    labels = {
+      "team" = "payments"
+    }
+  }
+
+  autoscale {
+    min_units   = 2
+    max_units   = 10
+    cpu_average = "800m"
+  }
+}
+
+resource "tsuru_app_process" "web" {
+  app   = tsuru_app.my-app.name
+  name  = "web"
+  units = 3
+}
@@ -0,0 +1,4 @@
+terraform import tsuru_app_quota.resource_name "app"
+
+# example
+terraform import tsuru_app_quota.my-app "sample-app"
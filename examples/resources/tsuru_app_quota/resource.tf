@@ -0,0 +1,4 @@
+resource "tsuru_app_quota" "my-app" {
+  app   = tsuru_app.my-app.name
+  limit = 20
+}
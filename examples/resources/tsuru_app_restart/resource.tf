@@ -0,0 +1,8 @@
+resource "tsuru_app_restart" "restart-web" {
+  app     = "sample-app"
+  process = "web"
+
+  triggers = {
+    secret_version = "2"
+  }
+}
@@ -3,6 +3,6 @@ resource "tsuru_app_router" "other-router" {
   name = "my-router"
 
   options = {
-    "key" = "value"
+    "domain" = "my-app.example.com"
   }
 }
@@ -0,0 +1,4 @@
+terraform import tsuru_app_teams.resource_name "app"
+
+# example
+terraform import tsuru_app_teams.my-app "sample-app"
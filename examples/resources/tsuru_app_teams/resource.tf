@@ -0,0 +1,4 @@
+resource "tsuru_app_teams" "my-app" {
+  app   = tsuru_app.my-app.name
+  teams = [tsuru_app.my-app.team_owner, "mysupport-team"]
+}
@@ -11,7 +11,8 @@ resource "tsuru_app_cname" "app-cname" {
 }
 
 resource "tsuru_certificate_issuer" "cert-for-cname" {
-  app    = tsuru_app.my-app.name
-  cname  = tsuru_app_cname.app-cname.hostname
-  issuer = "letsencrypt"
+  app            = tsuru_app.my-app.name
+  cname          = tsuru_app_cname.app-cname.hostname
+  issuer         = "letsencrypt"
+  wait_for_ready = true
 }
\ No newline at end of file
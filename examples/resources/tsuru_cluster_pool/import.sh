@@ -1,4 +1,7 @@
-terraform import tsuru_cluster_pool.resource_name "cluster/pool"
+terraform import tsuru_cluster_pool.resource_name "cluster::pool"
 
 # example
+terraform import tsuru_cluster_pool.cluster-pool "my-cluster::my-pool"
+
+# the cluster/pool format of older versions is also accepted
 terraform import tsuru_cluster_pool.cluster-pool "my-cluster/my-pool"
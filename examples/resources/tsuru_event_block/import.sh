@@ -0,0 +1,4 @@
+terraform import tsuru_event_block.resource_name "block_id"
+
+# example
+terraform import tsuru_event_block.release-freeze "65f1a2b3c4d5e6f7a8b9c0d1"
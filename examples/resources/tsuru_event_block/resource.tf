@@ -0,0 +1,9 @@
+resource "tsuru_event_block" "release-freeze" {
+  kind = "app.deploy"
+
+  conditions = {
+    pool = "prod"
+  }
+
+  reason = "release freeze, see the maintenance calendar"
+}
@@ -4,3 +4,15 @@ resource "tsuru_plan" "plan1" {
   memory  = "1Gi"
   default = true
 }
+
+# plans can't be updated, changes replace them with a new plan
+# that apps move to before the old one is removed
+resource "tsuru_plan" "medium" {
+  name_prefix = "medium-"
+  cpu         = "1"
+  memory      = "1Gi"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,4 @@
+terraform import tsuru_platform.resource_name "name"
+
+# example
+terraform import tsuru_platform.python "python"
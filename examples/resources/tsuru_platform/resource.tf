@@ -0,0 +1,13 @@
+resource "tsuru_platform" "python" {
+  name       = "python"
+  dockerfile = "${path.module}/platforms/python/Dockerfile"
+}
+
+resource "tsuru_platform" "node" {
+  name    = "node"
+  image   = "tsuru/node:20"
+  enabled = true
+
+  // pin the platform to a previous build while a broken image is fixed
+  rollback_image = "tsuru/node:v3"
+}
@@ -8,3 +8,14 @@ resource "tsuru_router" "test_router" {
       "x-my-header": test
     EOT
 }
+
+# config as a map, merged from module defaults
+resource "tsuru_router" "other_router" {
+  name = "other_router"
+  type = "router"
+  config_map = merge(var.router_defaults, {
+    url     = "testing"
+    timeout = 30
+    headers = jsonencode({ "x-my-header" = "test" })
+  })
+}
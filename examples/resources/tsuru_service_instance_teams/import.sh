@@ -0,0 +1,4 @@
+terraform import tsuru_service_instance_teams.resource_name "service::instance"
+
+# example
+terraform import tsuru_service_instance_teams.instance_teams "service01::my-instance"
@@ -0,0 +1,5 @@
+resource "tsuru_service_instance_teams" "instance_teams" {
+  service_name     = "service01"
+  service_instance = "my-instance"
+  teams            = ["my-team", "mysupport-team"]
+}
@@ -0,0 +1,4 @@
+terraform import tsuru_team_quota.resource_name "team"
+
+# example
+terraform import tsuru_team_quota.my-team "my-team"
@@ -0,0 +1,4 @@
+resource "tsuru_team_quota" "my-team" {
+  team  = "my-team"
+  limit = 10
+}
@@ -0,0 +1,4 @@
+terraform import tsuru_user_quota.resource_name "email"
+
+# example
+terraform import tsuru_user_quota.user "user@example.com"
@@ -0,0 +1,4 @@
+resource "tsuru_user_quota" "user" {
+  email = "user@example.com"
+  limit = 5
+}
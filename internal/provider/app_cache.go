@@ -0,0 +1,174 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// appCacheTTL is how long an app read is reused, it only needs to cover the
+// reads of the resources of the same app on a single refresh.
+const appCacheTTL = 10 * time.Second
+
+// appCache keeps the apps read by the provider for a short time, the
+// resources of an app (units, cnames, grants, ...) read the same app on every
+// refresh. Any write to an app drops it from the cache, and concurrent reads
+// of the same app share a single request.
+type appCache struct {
+	mu         sync.Mutex
+	entries    map[string]appCacheEntry
+	calls      map[string]*appCacheCall
+	generation uint64
+	now        func() time.Time
+}
+
+type appCacheEntry struct {
+	app     tsuru.App
+	expires time.Time
+}
+
+type appCacheCall struct {
+	done chan struct{}
+	app  tsuru.App
+	err  error
+}
+
+// get returns the cached app or loads it with fetch, errors are never cached.
+func (c *appCache) get(ctx context.Context, name string, fetch func(context.Context, string) (tsuru.App, error)) (tsuru.App, error) {
+	c.mu.Lock()
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if entry, ok := c.entries[name]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.app, nil
+	}
+
+	call, ok := c.calls[name]
+	if !ok {
+		call = &appCacheCall{done: make(chan struct{})}
+		if c.calls == nil {
+			c.calls = map[string]*appCacheCall{}
+		}
+		c.calls[name] = call
+		go c.load(ctx, name, call, c.generation, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.app, call.err
+	case <-ctx.Done():
+		return tsuru.App{}, ctx.Err()
+	}
+}
+
+// load runs fetch detached from the cancellation of the first caller, the
+// other callers may still be waiting for it. The app is only cached when no
+// write happened while it was being read.
+func (c *appCache) load(ctx context.Context, name string, call *appCacheCall, generation uint64, fetch func(context.Context, string) (tsuru.App, error)) {
+	call.app, call.err = fetch(context.WithoutCancel(ctx), name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.calls[name] == call {
+		delete(c.calls, name)
+	}
+	if call.err == nil && c.generation == generation {
+		if c.entries == nil {
+			c.entries = map[string]appCacheEntry{}
+		}
+		c.entries[name] = appCacheEntry{app: call.app, expires: c.now().Add(appCacheTTL)}
+	}
+	close(call.done)
+}
+
+// invalidate drops the app from the cache, an empty name drops every app.
+func (c *appCache) invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if name == "" {
+		c.entries = nil
+		c.calls = nil
+		return
+	}
+	delete(c.entries, name)
+	delete(c.calls, name)
+}
+
+// invalidateRequest drops the apps changed by a request, writes on an app
+// path only change that app, other writes (service binds, app creation, ...)
+// may change any app.
+func (c *appCache) invalidateRequest(method, path string) {
+	if method == http.MethodGet || method == http.MethodHead {
+		return
+	}
+	c.invalidate(appFromPath(path))
+}
+
+// appFromPath returns the app of paths like /1.0/apps/{app}/env.
+func appFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "apps" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// appCacheTransport invalidates the app cache on the writes sent by the
+// generated client. tsuru streams the progress of writes like deploys and app
+// updates, the write is only done when the body of the response is closed,
+// apps read before that are not kept.
+type appCacheTransport struct {
+	base  http.RoundTripper
+	cache *appCache
+}
+
+func (t *appCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.cache.invalidateRequest(req.Method, req.URL.Path)
+	resp, err := t.base.RoundTrip(req)
+	t.cache.invalidateRequest(req.Method, req.URL.Path)
+	if err != nil || resp.Body == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return resp, err
+	}
+
+	resp.Body = &appCacheBody{
+		ReadCloser: resp.Body,
+		invalidate: func() { t.cache.invalidateRequest(req.Method, req.URL.Path) },
+	}
+	return resp, nil
+}
+
+// appCacheBody invalidates the app cache once the body of a write is closed.
+type appCacheBody struct {
+	io.ReadCloser
+	invalidate func()
+	once       sync.Once
+}
+
+func (b *appCacheBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.invalidate)
+	return err
+}
+
+func (p *tsuruProvider) getApp(ctx context.Context, name string) (tsuru.App, error) {
+	return p.apps.get(ctx, name, func(ctx context.Context, name string) (tsuru.App, error) {
+		app, _, err := p.TsuruClient.AppApi.AppGet(ctx, name)
+		return app, err
+	})
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAppCache(t *testing.T) {
+	now := time.Now()
+	cache := &appCache{now: func() time.Time { return now }}
+
+	var calls int64
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		n := atomic.AddInt64(&calls, 1)
+		return tsuru.App{Name: name, Deploys: n}, nil
+	}
+
+	app, err := cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), app.Deploys)
+
+	app, err = cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), app.Deploys)
+
+	app, err = cache.get(context.Background(), "app02", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), app.Deploys)
+
+	cache.invalidateRequest(http.MethodGet, "/1.0/apps/app01")
+	app, err = cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), app.Deploys)
+
+	cache.invalidateRequest(http.MethodPost, "/1.0/apps/app01/env")
+	app, err = cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), app.Deploys)
+
+	app, err = cache.get(context.Background(), "app02", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), app.Deploys)
+
+	cache.invalidateRequest(http.MethodPut, "/1.0/services/mysql/instances/db/app01")
+	app, err = cache.get(context.Background(), "app02", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), app.Deploys)
+
+	now = now.Add(appCacheTTL)
+	app, err = cache.get(context.Background(), "app02", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), app.Deploys)
+}
+
+func TestAppCacheErrorsAreNotCached(t *testing.T) {
+	cache := &appCache{}
+
+	var calls int64
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			return tsuru.App{}, errors.New("unavailable")
+		}
+		return tsuru.App{Name: name}, nil
+	}
+
+	_, err := cache.get(context.Background(), "app01", fetch)
+	assert.EqualError(t, err, "unavailable")
+
+	app, err := cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "app01", app.Name)
+}
+
+func TestAppCacheSingleflight(t *testing.T) {
+	cache := &appCache{}
+
+	var calls int64
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		atomic.AddInt64(&calls, 1)
+		<-release
+		return tsuru.App{Name: name}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			app, err := cache.get(context.Background(), "app01", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "app01", app.Name)
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		return cache.calls["app01"] != nil
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&calls))
+}
+
+func TestAppCacheWriteDuringRead(t *testing.T) {
+	cache := &appCache{}
+
+	var calls int64
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		n := atomic.AddInt64(&calls, 1)
+		if n == 1 {
+			close(fetching)
+			<-release
+		}
+		return tsuru.App{Name: name, Deploys: n}, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app, err := cache.get(context.Background(), "app01", fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), app.Deploys)
+	}()
+
+	<-fetching
+	cache.invalidate("app01")
+	close(release)
+	<-done
+
+	app, err := cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), app.Deploys)
+}
+
+func TestAppCacheCanceledWaiter(t *testing.T) {
+	cache := &appCache{}
+
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		<-release
+		return tsuru.App{Name: name}, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := cache.get(ctx, "app01", fetch)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	app, err := cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "app01", app.Name)
+}
+
+func TestAppCacheTransportStreamedWrite(t *testing.T) {
+	cache := &appCache{}
+	body := make(chan string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for line := range body {
+			fmt.Fprintln(w, line)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &appCacheTransport{base: http.DefaultTransport, cache: cache}}
+
+	var version int64
+	fetch := func(ctx context.Context, name string) (tsuru.App, error) {
+		return tsuru.App{Name: name, Deploys: atomic.AddInt64(&version, 1)}, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/1.0/apps/app01/deploy", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	// read while tsuru is still streaming the deploy
+	app, err := cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, app.Deploys)
+
+	body <- "deploying"
+	close(body)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	app, err = cache.get(context.Background(), "app01", fetch)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, app.Deploys)
+}
+
+func TestAppFromPath(t *testing.T) {
+	tests := map[string]string{
+		"/1.0/apps/app01":                        "app01",
+		"/1.0/apps/app01/env":                    "app01",
+		"/api/1.0/apps/app01/cname":              "app01",
+		"/1.0/apps":                              "",
+		"/1.0/services/mysql/instances/db/app01": "",
+		"/1.0/jobs/job01":                        "",
+	}
+
+	for path, expected := range tests {
+		assert.Equal(t, expected, appFromPath(path), path)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// catalogCache keeps the platforms, pools and plans listed by the provider,
+// they are checked on the plan of every app and job and rarely change during
+// a run. Lookups that miss are retried on a fresh list, so items created on
+// the same run are found.
+type catalogCache struct {
+	mu        sync.Mutex
+	platforms []tsuru.Platform
+	pools     []tsuru.Pool
+	plans     []tsuru.Plan
+}
+
+// cachedList returns the cached list, loading it when it is not cached or
+// refresh is set, fresh reports whether the list was just loaded.
+func cachedList[T any](ctx context.Context, mu *sync.Mutex, cached *[]T, refresh bool, list func(context.Context) ([]T, *http.Response, error)) (result []T, fresh bool, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if *cached != nil && !refresh {
+		return *cached, false, nil
+	}
+
+	items, _, err := list(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+
+	*cached = items
+	return items, true, nil
+}
+
+func (p *tsuruProvider) listPlatforms(ctx context.Context, refresh bool) ([]tsuru.Platform, bool, error) {
+	return cachedList(ctx, &p.catalog.mu, &p.catalog.platforms, refresh, p.TsuruClient.PlatformApi.PlatformList)
+}
+
+func (p *tsuruProvider) listPools(ctx context.Context, refresh bool) ([]tsuru.Pool, bool, error) {
+	return cachedList(ctx, &p.catalog.mu, &p.catalog.pools, refresh, p.TsuruClient.PoolApi.PoolList)
+}
+
+func (p *tsuruProvider) listPlans(ctx context.Context, refresh bool) ([]tsuru.Plan, bool, error) {
+	return cachedList(ctx, &p.catalog.mu, &p.catalog.plans, refresh, p.TsuruClient.PlanApi.PlanList)
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestCachedList(t *testing.T) {
+	var mu sync.Mutex
+	var cached []tsuru.Plan
+
+	calls := 0
+	list := func(ctx context.Context) ([]tsuru.Plan, *http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, nil, nil
+		}
+		return []tsuru.Plan{{Name: "c2m4"}}, nil, nil
+	}
+
+	plans, fresh, err := cachedList(context.Background(), &mu, &cached, false, list)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+	assert.Empty(t, plans)
+
+	// empty lists are cached too
+	_, fresh, err = cachedList(context.Background(), &mu, &cached, false, list)
+	require.NoError(t, err)
+	assert.False(t, fresh)
+	assert.Equal(t, 1, calls)
+
+	plans, fresh, err = cachedList(context.Background(), &mu, &cached, true, list)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+	assert.Equal(t, []tsuru.Plan{{Name: "c2m4"}}, plans)
+	assert.Equal(t, 2, calls)
+}
@@ -139,7 +139,7 @@ func dataSourceTsuruAppRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	name := d.Get("name").(string)
 
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, name)
+	app, err := provider.getApp(ctx, name)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,143 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTsuruAppCertificates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Certificates served by the routers of a tsuru application",
+		ReadContext: dataSourceTsuruAppCertificatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Description: "Certificates by router and cname",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"router": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer": {
+							Type:        schema.TypeString,
+							Description: "Certificate issuer of the cname, empty for custom certificates",
+							Computed:    true,
+						},
+						"ready": {
+							Type:        schema.TypeBool,
+							Description: "If the certificate was issued",
+							Computed:    true,
+						},
+						"not_before": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"not_after": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"days_remaining": {
+							Type:        schema.TypeInt,
+							Description: "Whole days until the certificate expires, negative when expired",
+							Computed:    true,
+						},
+						"dns_names": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"certificate": {
+							Type:        schema.TypeString,
+							Description: "PEM certificate chain",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTsuruAppCertificatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+
+	certificates, _, err := provider.TsuruClient.AppApi.AppGetCertificates(ctx, app)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read certificates of app %s", app)
+	}
+
+	result := []map[string]interface{}{}
+	for routerName, router := range certificates.Routers {
+		for cname, certificate := range router.Cnames {
+			item := map[string]interface{}{
+				"router":      routerName,
+				"cname":       cname,
+				"issuer":      certificate.Issuer,
+				"ready":       certificate.Certificate != "",
+				"dns_names":   []interface{}{},
+				"certificate": certificate.Certificate,
+			}
+
+			if certificate.Certificate != "" {
+				leaf, err := parseLeafCertificate(certificate.Certificate)
+				if err != nil {
+					return diag.Errorf("unable to parse certificate of %s on router %s: %v", cname, routerName, err)
+				}
+				for key, value := range flattenCertificateValidity(leaf, time.Now()) {
+					item[key] = value
+				}
+			}
+
+			result = append(result, item)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i]["router"] != result[j]["router"] {
+			return result[i]["router"].(string) < result[j]["router"].(string)
+		}
+		return result[i]["cname"].(string) < result[j]["cname"].(string)
+	})
+
+	d.SetId(app)
+	d.Set("certificates", result)
+
+	return nil
+}
+
+func flattenCertificateValidity(certificate *x509.Certificate, now time.Time) map[string]interface{} {
+	dnsNames := []interface{}{}
+	for _, name := range certificate.DNSNames {
+		dnsNames = append(dnsNames, name)
+	}
+
+	return map[string]interface{}{
+		"not_before":     certificate.NotBefore.UTC().Format(time.RFC3339),
+		"not_after":      certificate.NotAfter.UTC().Format(time.RFC3339),
+		"days_remaining": int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24)),
+		"dns_names":      dnsNames,
+	}
+}
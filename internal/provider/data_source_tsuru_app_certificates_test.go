@@ -0,0 +1,96 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccDataSourceTsuruAppCertificates(t *testing.T) {
+	fakeServer := echo.New()
+
+	certificate, _ := testCertificate(t, "*.app.tsuru.io")
+
+	fakeServer.GET("/1.24/apps/:app/certificate", func(c echo.Context) error {
+		assert.Equal(t, "app01", c.Param("app"))
+		return c.JSON(http.StatusOK, tsuru.AppCertificates{
+			Routers: map[string]tsuru.AppCertificatesRouters{
+				"https-router": {
+					Cnames: map[string]tsuru.AppCertificatesCnames{
+						"www.app.tsuru.io": {Certificate: certificate},
+						"api.app.tsuru.io": {Issuer: "lets-encrypt"},
+					},
+				},
+			},
+		})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	dataSourceName := "data.tsuru_app_certificates.certificates"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "tsuru_app_certificates" "certificates" {
+  app = "app01"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "app01"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.0.router", "https-router"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.0.cname", "api.app.tsuru.io"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.0.issuer", "lets-encrypt"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.0.ready", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.0.not_after", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.cname", "www.app.tsuru.io"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.issuer", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.ready", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.days_remaining", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.dns_names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.dns_names.0", "*.app.tsuru.io"),
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.1.certificate", certificate),
+					resource.TestCheckResourceAttrSet(dataSourceName, "certificates.1.not_before"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "certificates.1.not_after"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenCertificateValidity(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	certificate := &x509.Certificate{
+		NotBefore: now.Add(-24 * time.Hour),
+		NotAfter:  now.Add(10*24*time.Hour + time.Hour),
+		DNSNames:  []string{"www.app.tsuru.io"},
+	}
+
+	validity := flattenCertificateValidity(certificate, now)
+	assert.Equal(t, "2025-12-31T12:00:00Z", validity["not_before"])
+	assert.Equal(t, "2026-01-11T13:00:00Z", validity["not_after"])
+	assert.Equal(t, 10, validity["days_remaining"])
+	assert.Equal(t, []interface{}{"www.app.tsuru.io"}, validity["dns_names"])
+
+	certificate.NotAfter = now.Add(-time.Hour)
+	assert.Equal(t, -1, flattenCertificateValidity(certificate, now)["days_remaining"])
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func dataSourceTsuruEvents() *schema.Resource {
+	return &schema.Resource{
+		Description: "Recent tsuru events, newest first",
+		ReadContext: dataSourceTsuruEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"kinds": {
+				Type:        schema.TypeList,
+				Description: "Event kind names (e.g. app.deploy)",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"target_type": {
+				Type:        schema.TypeString,
+				Description: "Event target type (e.g. app)",
+				Optional:    true,
+			},
+			"target_value": {
+				Type:        schema.TypeString,
+				Description: "Event target value (e.g. the app name)",
+				Optional:    true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Description:  "Event status: running, failed or succeeded",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "failed", "succeeded"}, false),
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "Maximum number of events fetched (default = 20), succeeded events are filtered after fetching",
+				Optional:    true,
+				Default:     20,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target_value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"running": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTsuruEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	kinds := []string{}
+	for _, kind := range d.Get("kinds").([]interface{}) {
+		kinds = append(kinds, kind.(string))
+	}
+	targetType := d.Get("target_type").(string)
+	targetValue := d.Get("target_value").(string)
+	limit := d.Get("limit").(int)
+
+	query := eventListQuery(kinds, targetType, targetValue, limit)
+
+	status := d.Get("status").(string)
+	switch status {
+	case "running":
+		query.Set("running", "true")
+	case "failed":
+		query.Set("errorOnly", "true")
+	}
+
+	events, err := listEvents(ctx, provider, query)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to list events")
+	}
+
+	result := []interface{}{}
+	for _, event := range events {
+		if status == "succeeded" && (event.Running || event.Error != "") {
+			continue
+		}
+		result = append(result, flattenEvent(event))
+	}
+
+	d.SetId(createID([]string{"events", strings.Join(kinds, ","), targetType, targetValue, status, strconv.Itoa(limit)}))
+	d.Set("events", result)
+
+	return nil
+}
+
+// eventListQuery returns the filters of the event list with a kindname per
+// kind, the generated client joins the kinds on a kindNames parameter which
+// tsuru ignores.
+func eventListQuery(kinds []string, targetType, targetValue string, limit int) url.Values {
+	query := url.Values{}
+	for _, kind := range kinds {
+		query.Add("kindname", kind)
+	}
+	if targetType != "" {
+		query.Set("target.type", targetType)
+	}
+	if targetValue != "" {
+		query.Set("target.value", targetValue)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return query
+}
+
+func listEvents(ctx context.Context, provider *tsuruProvider, query url.Values) ([]tsuru.Event, error) {
+	resp, err := tsuruRequest(ctx, provider, http.MethodGet, "/1.1/events?"+query.Encode(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	var events []tsuru.Event
+	if err = json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func flattenEvent(event tsuru.Event) map[string]interface{} {
+	m := map[string]interface{}{
+		"id":           event.UniqueID,
+		"kind":         event.Kind.Name,
+		"target_type":  event.Target.Type,
+		"target_value": event.Target.Value,
+		"owner":        event.Owner.Name,
+		"running":      event.Running,
+		"error":        event.Error,
+		"start_time":   "",
+		"end_time":     "",
+	}
+
+	if !event.StartTime.IsZero() {
+		m["start_time"] = event.StartTime.Format(time.RFC3339)
+	}
+	if !event.EndTime.IsZero() {
+		m["end_time"] = event.EndTime.Format(time.RFC3339)
+	}
+
+	return m
+}
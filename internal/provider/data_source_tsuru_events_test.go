@@ -0,0 +1,101 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccDatasourceTsuruEvents(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.1/events", func(c echo.Context) error {
+		assert.Equal(t, []string{"app.deploy", "app.update.restart"}, c.QueryParams()["kindname"])
+		assert.Equal(t, "", c.QueryParam("kindNames"))
+		assert.Equal(t, "app", c.QueryParam("target.type"))
+		assert.Equal(t, "app01", c.QueryParam("target.value"))
+		assert.Equal(t, "10", c.QueryParam("limit"))
+		assert.Equal(t, "", c.QueryParam("running"))
+		assert.Equal(t, "", c.QueryParam("errorOnly"))
+
+		return c.JSON(http.StatusOK, []tsuru.Event{
+			{
+				UniqueID:  "event-3",
+				Kind:      tsuru.EventKind{Type: "permission", Name: "app.deploy"},
+				Target:    tsuru.EventTarget{Type: "app", Value: "app01"},
+				Owner:     tsuru.EventOwner{Type: "user", Name: "user@example.com"},
+				StartTime: time.Date(2026, 10, 1, 12, 5, 0, 0, time.UTC),
+				Running:   true,
+			},
+			{
+				UniqueID:  "event-2",
+				Kind:      tsuru.EventKind{Type: "permission", Name: "app.deploy"},
+				Target:    tsuru.EventTarget{Type: "app", Value: "app01"},
+				Owner:     tsuru.EventOwner{Type: "user", Name: "user@example.com"},
+				StartTime: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 10, 1, 12, 1, 0, 0, time.UTC),
+				Error:     "deploy failed",
+			},
+			{
+				UniqueID:  "event-1",
+				Kind:      tsuru.EventKind{Type: "permission", Name: "app.deploy"},
+				Target:    tsuru.EventTarget{Type: "app", Value: "app01"},
+				Owner:     tsuru.EventOwner{Type: "team", Name: "my-team"},
+				StartTime: time.Date(2026, 9, 30, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 9, 30, 10, 2, 0, 0, time.UTC),
+			},
+		})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceTsuruEvents_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "id", "events::app.deploy,app.update.restart::app::app01::succeeded::10"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.#", "1"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.id", "event-1"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.kind", "app.deploy"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.target_type", "app"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.target_value", "app01"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.owner", "my-team"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.start_time", "2026-09-30T10:00:00Z"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.end_time", "2026-09-30T10:02:00Z"),
+					resource.TestCheckResourceAttr("data.tsuru_events.deploys", "events.0.running", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceTsuruEvents_basic() string {
+	return `
+	data "tsuru_events" "deploys" {
+		kinds        = ["app.deploy", "app.update.restart"]
+		target_type  = "app"
+		target_value = "app01"
+		status       = "succeeded"
+		limit        = 10
+	}
+`
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+var quotaOwners = []string{"app", "team", "user"}
+
+func dataSourceTsuruQuota() *schema.Resource {
+	return &schema.Resource{
+		Description: "Quota of a tsuru app, team or user",
+		ReadContext: dataSourceTsuruQuotaRead,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:         schema.TypeString,
+				Description:  "Application name, its quota limits the number of units",
+				Optional:     true,
+				ExactlyOneOf: quotaOwners,
+			},
+			"team": {
+				Type:         schema.TypeString,
+				Description:  "Team name, its quota limits the number of apps",
+				Optional:     true,
+				ExactlyOneOf: quotaOwners,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Description:  "User email, its quota limits the number of apps",
+				Optional:     true,
+				ExactlyOneOf: quotaOwners,
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "Quota limit, -1 means unlimited",
+				Computed:    true,
+			},
+			"in_use": {
+				Type:        schema.TypeInt,
+				Description: "Quota in use",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceTsuruQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	var quota tsuru_client.Quota
+	var err error
+	kind, name := "", ""
+	if app, ok := d.GetOk("app"); ok {
+		kind, name = "app", app.(string)
+		quota, err = getAppQuota(ctx, provider, name)
+	} else if team, ok := d.GetOk("team"); ok {
+		kind, name = "team", team.(string)
+		quota, err = getTeamQuota(ctx, provider, name)
+	} else if user, ok := d.GetOk("user"); ok {
+		kind, name = "user", user.(string)
+		quota, err = getUserQuota(ctx, provider, name)
+	} else {
+		return diag.Errorf("one of %s must be specified", strings.Join(quotaOwners, ", "))
+	}
+
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read quota of %s %s", kind, name)
+	}
+
+	d.SetId(createID([]string{kind, name}))
+	flattenQuota(d, quota)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	echo "github.com/labstack/echo/v4"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccDatasourceTsuruQuota(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: 10, Inuse: 4})
+	})
+
+	fakeServer.GET("/1.12/teams/:team/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.UserQuotaViewResponse{Limit: 5, Inuse: 3})
+	})
+
+	fakeServer.GET("/1.0/users/:email/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.UserQuotaViewResponse{Limit: unlimitedQuota, Inuse: 1})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceTsuruQuota_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tsuru_quota.app", "limit", "10"),
+					resource.TestCheckResourceAttr("data.tsuru_quota.app", "in_use", "4"),
+					resource.TestCheckResourceAttr("data.tsuru_quota.team", "limit", "5"),
+					resource.TestCheckResourceAttr("data.tsuru_quota.team", "in_use", "3"),
+					resource.TestCheckResourceAttr("data.tsuru_quota.user", "limit", "-1"),
+					resource.TestCheckResourceAttr("data.tsuru_quota.user", "in_use", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceTsuruQuota_basic() string {
+	return `
+	data "tsuru_quota" "app" {
+		app = "app01"
+	}
+
+	data "tsuru_quota" "team" {
+		team = "my-team"
+	}
+
+	data "tsuru_quota" "user" {
+		user = "user@example.com"
+	}
+`
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// tsuruDiagnostics returns err as a diagnostic summarized by the action of
+// the resource, e.g. "unable to update envs of app app01", the detail tells
+// whether the tsuru API rejected the request or could not be reached.
+func tsuruDiagnostics(err error, format string, args ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf(format, args...),
+		Detail:   describeTsuruError(err),
+	}}
+}
+
+// tsuruResourceDiagnostics is tsuruDiagnostics pointing to the attribute of
+// d the tsuru API rejected. attrs are the attributes sent on the request,
+// nested ones as "process.plan", checked in order for a value quoted by the
+// error message and then for their name, like "pool not found".
+func tsuruResourceDiagnostics(d *schema.ResourceData, attrs []string, err error, format string, args ...interface{}) diag.Diagnostics {
+	diags := tsuruDiagnostics(err, format, args...)
+	apiErr, ok := parseTsuruAPIError(err)
+	if !ok || apiErr.StatusCode < 400 || apiErr.StatusCode >= 500 ||
+		apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden {
+		return diags
+	}
+	diags[0].AttributePath = attributePath(d, attrs, apiErr.Message)
+	return diags
+}
+
+func describeTsuruError(err error) string {
+	if apiErr, ok := parseTsuruAPIError(err); ok {
+		message := apiErr.Message
+		if message == "" {
+			message = http.StatusText(apiErr.StatusCode)
+		}
+		detail := fmt.Sprintf("tsuru API responded with status code %d: %s", apiErr.StatusCode, message)
+		if hint := apiErr.hint(); hint != "" {
+			detail += "\n\n" + hint
+		}
+		return detail
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Sprintf("timed out waiting for the tsuru API: %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return fmt.Sprintf("canceled: %v", err)
+	}
+	if isTransientError(nil, err) {
+		return fmt.Sprintf("unable to reach the tsuru API, the request was retried as configured by max_retries: %v", err)
+	}
+
+	return err.Error()
+}
+
+// tsuruAPIError is an error response of the tsuru API.
+type tsuruAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *tsuruAPIError) hint() string {
+	switch e.StatusCode {
+	case http.StatusForbidden:
+		return "The token used by the provider is not allowed to do this action, tsuru does not tell which permission is missing: a tsuru admin must grant the permission of the action to one of the roles of the token."
+	case http.StatusConflict:
+		if isRetryableError([]byte(e.Message)) {
+			return "Another action is running on the same target and did not finish before the timeout of the resource, apply again when it finishes."
+		}
+		return "The request conflicts with the current state in tsuru, it may have been changed outside of Terraform: refresh the state, or import the resource when it already exists."
+	}
+	return ""
+}
+
+// parseTsuruAPIError returns the status code and the message of the errors
+// returned by the generated client and by tsuruRequest.
+func parseTsuruAPIError(err error) (*tsuruAPIError, bool) {
+	var apiError tsuru_client.GenericOpenAPIError
+	if errors.As(err, &apiError) && apiError.StatusCode() != 0 {
+		return parseTsuruErrorBody(apiError.StatusCode(), apiError.Body()), true
+	}
+	var requestErr *tsuruRequestError
+	if errors.As(err, &requestErr) {
+		return parseTsuruErrorBody(requestErr.StatusCode, []byte(requestErr.Message)), true
+	}
+	return nil, false
+}
+
+// parseTsuruErrorBody reads the body of an error response, tsuru responds
+// with a plain message and a few routes with a JSON object or string.
+func parseTsuruErrorBody(statusCode int, body []byte) *tsuruAPIError {
+	apiErr := &tsuruAPIError{StatusCode: statusCode}
+	body = bytes.TrimSpace(body)
+
+	var object map[string]interface{}
+	var str string
+	switch {
+	case json.Unmarshal(body, &object) == nil && object != nil:
+		for _, key := range []string{"message", "Message", "error", "Error", "msg"} {
+			if message, ok := object[key].(string); ok {
+				apiErr.Message = strings.TrimSpace(message)
+				break
+			}
+		}
+		if apiErr.Message == "" {
+			apiErr.Message = string(body)
+		}
+	case json.Unmarshal(body, &str) == nil:
+		apiErr.Message = strings.TrimSpace(str)
+	default:
+		apiErr.Message = string(body)
+	}
+
+	return apiErr
+}
+
+// attributePath returns the path of the attribute in attrs the message is
+// about, or nil. The attribute whose value is mentioned first is the subject
+// of the message, like pool in "pool prod does not allow plan c8m16", then
+// the first attribute mentioned by name, like "pool not found". Elements of
+// sets can not be addressed, the path points to the whole set.
+func attributePath(d *schema.ResourceData, attrs []string, message string) cty.Path {
+	var path cty.Path
+	first := -1
+	for _, attr := range attrs {
+		p, i := attributePathForValue(d, attr, message)
+		if i >= 0 && (first < 0 || i < first) {
+			path, first = p, i
+		}
+	}
+	if path != nil {
+		return path
+	}
+
+	for _, attr := range attrs {
+		if strings.Contains(attr, ".") {
+			continue
+		}
+		i := wordIndex(message, attr)
+		if i < 0 {
+			i = wordIndex(message, strings.ReplaceAll(attr, "_", " "))
+		}
+		if i >= 0 && (first < 0 || i < first) {
+			path, first = cty.GetAttrPath(attr), i
+		}
+	}
+	return path
+}
+
+func attributePathForValue(d *schema.ResourceData, attr, message string) (cty.Path, int) {
+	block, field, nested := strings.Cut(attr, ".")
+	if !nested {
+		value, _ := d.Get(attr).(string)
+		return cty.GetAttrPath(attr), wordIndex(message, value)
+	}
+
+	switch elements := d.Get(block).(type) {
+	case []interface{}:
+		for i, element := range elements {
+			if index := elementWordIndex(element, field, message); index >= 0 {
+				return cty.GetAttrPath(block).IndexInt(i).GetAttr(field), index
+			}
+		}
+	case *schema.Set:
+		for _, element := range elements.List() {
+			if index := elementWordIndex(element, field, message); index >= 0 {
+				return cty.GetAttrPath(block), index
+			}
+		}
+	}
+	return nil, -1
+}
+
+func elementWordIndex(element interface{}, field, message string) int {
+	m, _ := element.(map[string]interface{})
+	value, _ := m[field].(string)
+	return wordIndex(message, value)
+}
+
+// wordIndex returns the index of word in s not as part of a longer name,
+// "c2m4" is not in "c2m4-large", or -1.
+func wordIndex(s, word string) int {
+	if word == "" {
+		return -1
+	}
+	s, word = strings.ToLower(s), strings.ToLower(word)
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(word)
+		if (start == 0 || !isNameByte(s[start-1])) && wordEnds(s[end:]) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+// wordEnds reports whether a word followed by rest is not part of a longer
+// name, a dot ends it only at the end of a sentence.
+func wordEnds(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	if rest[0] == '.' {
+		return len(rest) == 1 || strings.ContainsAny(rest[1:2], " \t\r\n")
+	}
+	return !isNameByte(rest[0])
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == ':' || c == '/' || c == '-'
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTsuruDiagnostics(t *testing.T) {
+	diags := tsuruDiagnostics(&tsuruRequestError{StatusCode: 400, Message: "invalid env name\n"}, "unable to update envs of app %s", "app01")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "unable to update envs of app app01", diags[0].Summary)
+	assert.Equal(t, "tsuru API responded with status code 400: invalid env name", diags[0].Detail)
+}
+
+func TestDescribeTsuruError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{
+			err:      &tsuruRequestError{StatusCode: 404},
+			expected: "tsuru API responded with status code 404: Not Found",
+		},
+		{
+			err:      &url.Error{Op: "Post", URL: "http://tsuru/1.0/apps/app01/env", Err: io.EOF},
+			expected: `unable to reach the tsuru API, the request was retried as configured by max_retries: Post "http://tsuru/1.0/apps/app01/env": EOF`,
+		},
+		{
+			err:      &url.Error{Op: "Post", URL: "http://tsuru/1.0/apps/app01/env", Err: context.DeadlineExceeded},
+			expected: `timed out waiting for the tsuru API: Post "http://tsuru/1.0/apps/app01/env": context deadline exceeded`,
+		},
+		{
+			err:      context.Canceled,
+			expected: "canceled: context canceled",
+		},
+		{
+			err:      &tsuruRequestError{StatusCode: 403, Message: "You don't have permission to do this action\n"},
+			expected: "tsuru API responded with status code 403: You don't have permission to do this action\n\nThe token used by the provider is not allowed to do this action, tsuru does not tell which permission is missing: a tsuru admin must grant the permission of the action to one of the roles of the token.",
+		},
+		{
+			err:      &tsuruRequestError{StatusCode: 409, Message: "token already exists"},
+			expected: "tsuru API responded with status code 409: token already exists\n\nThe request conflicts with the current state in tsuru, it may have been changed outside of Terraform: refresh the state, or import the resource when it already exists.",
+		},
+		{
+			err:      &tsuruRequestError{StatusCode: 409, Message: "event locked: app.update.env.set"},
+			expected: "tsuru API responded with status code 409: event locked: app.update.env.set\n\nAnother action is running on the same target and did not finish before the timeout of the resource, apply again when it finishes.",
+		},
+		{
+			err:      errors.New("unexpected error"),
+			expected: "unexpected error",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, describeTsuruError(tt.err))
+	}
+}
+
+func TestParseTsuruErrorBody(t *testing.T) {
+	tests := map[string]tsuruAPIError{
+		"pool not found\n":                        {StatusCode: 400, Message: "pool not found"},
+		`{"Message":"plan not found","Code":400}`: {StatusCode: 400, Message: "plan not found"},
+		`{"error":"invalid team"}`:                {StatusCode: 400, Message: "invalid team"},
+		`"invalid platform"`:                      {StatusCode: 400, Message: "invalid platform"},
+		`{"Status":"failed"}`:                     {StatusCode: 400, Message: `{"Status":"failed"}`},
+	}
+
+	for body, expected := range tests {
+		assert.Equal(t, &expected, parseTsuruErrorBody(400, []byte(body)), body)
+	}
+}
+
+func TestAttributePath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceTsuruApplicationSchema(), map[string]interface{}{
+		"name":       "app01",
+		"platform":   "python",
+		"plan":       "c2m4",
+		"pool":       "prod",
+		"team_owner": "my-team",
+		"process": []interface{}{
+			map[string]interface{}{"name": "worker", "plan": "c8m16"},
+		},
+	})
+
+	tests := map[string]cty.Path{
+		`pool "prod" does not allow plan c2m4`:     cty.GetAttrPath("pool"),
+		`plan "c2m4" is not enabled for pool prod`: cty.GetAttrPath("plan"),
+		"plan c8m16 not found":                     cty.GetAttrPath("process"),
+		"team not found":                           nil,
+		"team owner is required":                   cty.GetAttrPath("team_owner"),
+		"Pool not found.":                          cty.GetAttrPath("pool"),
+		"plan c2m4-large not found":                cty.GetAttrPath("plan"),
+		"invalid app name":                         nil,
+	}
+
+	for message, expected := range tests {
+		assert.Equal(t, expected, attributePath(d, appRequestAttributes, message), message)
+	}
+}
+
+func TestWordIndex(t *testing.T) {
+	tests := []struct {
+		s        string
+		word     string
+		expected int
+	}{
+		{"plan c2m4 not found", "c2m4", 5},
+		{"plan C2M4 not found", "c2m4", 5},
+		{"plan c2m4-large not found", "c2m4", -1},
+		{"plan c2m4-large or c2m4 not found", "c2m4", 19},
+		{"not found plan c2m4.", "c2m4", 15},
+		{"not found plan c2m4. try again", "c2m4", 15},
+		{"image registry/c2m4.1 not found", "c2m4", -1},
+		{`pool "prod" not found`, "prod", 6},
+		{"pool prod:1 not found", "prod", -1},
+		{"plan not found", "", -1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, wordIndex(tt.s, tt.word), tt.s)
+	}
+}
+
+// closeConnection makes the fake server drop the request without a
+// response, the client fails with a transport error.
+func closeConnection(c echo.Context) error {
+	conn, _, err := c.Response().Hijack()
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
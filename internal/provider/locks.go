@@ -0,0 +1,107 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// targetLocks serializes the mutations sent by the provider to the same
+// target, tsuru fails concurrent actions on a target with "event locked"
+// and retrying them makes large applies thrash. The retries on event locked
+// remain for the actions started outside of the provider.
+type targetLocks struct {
+	mu    sync.Mutex
+	locks map[string]*targetLock
+}
+
+type targetLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+func appLock(name string) string {
+	return createID([]string{"app", name})
+}
+
+func jobLock(name string) string {
+	return createID([]string{"job", name})
+}
+
+func clusterLock(name string) string {
+	return createID([]string{"cluster", name})
+}
+
+func serviceInstanceLock(service, instance string) string {
+	return createID([]string{"service-instance", service, instance})
+}
+
+// lock waits for the locks of all keys, they are always taken in the same
+// order so mutations locking more than one target do not deadlock. The
+// returned function releases every lock, only its first call has effect so
+// the locks can be released before waiting for tsuru while still deferred.
+func (l *targetLocks) lock(ctx context.Context, keys ...string) (func(), error) {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	unlocks := []func(){}
+	var once sync.Once
+	unlock := func() {
+		once.Do(func() {
+			for i := len(unlocks) - 1; i >= 0; i-- {
+				unlocks[i]()
+			}
+		})
+	}
+
+	for _, key := range keys {
+		release, err := l.lockKey(ctx, key)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		unlocks = append(unlocks, release)
+	}
+
+	return unlock, nil
+}
+
+func (l *targetLocks) lockKey(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*targetLock{}
+	}
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &targetLock{sem: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	select {
+	case lock.sem <- struct{}{}:
+		return func() {
+			<-lock.sem
+			l.release(key, lock)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, lock)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *targetLocks) release(key string, lock *targetLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetLocks(t *testing.T) {
+	locks := &targetLocks{}
+
+	var mu sync.Mutex
+	running := map[string]int{}
+	maxRunning := map[string]int{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		key := appLock("app01")
+		if i%2 == 1 {
+			key = appLock("app02")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.lock(context.Background(), key)
+			require.NoError(t, err)
+			defer unlock()
+
+			mu.Lock()
+			running[key]++
+			if running[key] > maxRunning[key] {
+				maxRunning[key] = running[key]
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			running[key]--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{appLock("app01"): 1, appLock("app02"): 1}, maxRunning)
+	assert.Empty(t, locks.locks)
+}
+
+func TestTargetLocksMultipleKeys(t *testing.T) {
+	locks := &targetLocks{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		keys := []string{appLock("app01"), serviceInstanceLock("mysql", "db")}
+		if i%2 == 1 {
+			keys = []string{serviceInstanceLock("mysql", "db"), appLock("app01"), appLock("app01")}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.lock(context.Background(), keys...)
+			require.NoError(t, err)
+			unlock()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("locks taken in opposite orders deadlocked")
+	}
+	assert.Empty(t, locks.locks)
+}
+
+func TestTargetLocksContextCanceled(t *testing.T) {
+	locks := &targetLocks{}
+
+	unlock, err := locks.lock(context.Background(), jobLock("job01"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = locks.lock(ctx, clusterLock("cluster01"), jobLock("job01"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+	assert.Empty(t, locks.locks)
+
+	unlock, err = locks.lock(context.Background(), clusterLock("cluster01"), jobLock("job01"))
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestTargetLocksUnlockTwice(t *testing.T) {
+	locks := &targetLocks{}
+
+	unlock, err := locks.lock(context.Background(), appLock("app01"))
+	require.NoError(t, err)
+	unlock()
+
+	other, err := locks.lock(context.Background(), appLock("app01"))
+	require.NoError(t, err)
+
+	// a second call must not release the lock taken by other
+	unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = locks.lock(ctx, appLock("app01"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	other()
+	assert.Empty(t, locks.locks)
+}
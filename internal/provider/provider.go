@@ -8,16 +8,20 @@ import (
 	"bufio"
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tsuru/go-tsuruclient/pkg/client"
 	"github.com/tsuru/go-tsuruclient/pkg/config"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -52,24 +56,95 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("TSURU_FULL_MANAGEMENT_OF_USER_ENVIRONMENT_VARIABLES", nil),
 			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Description: "Tags added to every app, job and service instance, shown on their tags_all instead of tags",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"default_metadata": {
+				Type:        schema.TypeList,
+				Description: "Labels and annotations added to every app and job, shown on their metadata_all instead of metadata",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"labels": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"annotations": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"default_team_owner": {
+				Type:        schema.TypeString,
+				Description: "Team owner of apps, jobs, service instances and volumes that don't set one",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TSURU_DEFAULT_TEAM_OWNER", nil),
+			},
+			"default_pool": {
+				Type:        schema.TypeString,
+				Description: "Pool of apps, jobs and volumes that don't set one, service instances never use it",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TSURU_DEFAULT_POOL", nil),
+			},
+			"default_plan": {
+				Type:        schema.TypeString,
+				Description: "Plan of apps and jobs that don't set one",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TSURU_DEFAULT_PLAN", nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum retries of a request failed with a connection error, a timeout or the status codes 429, 502, 503 and 504, only reads and idempotent writes are retried. (Default: 3)",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TSURU_MAX_RETRIES", defaultRetryPolicy.MaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum seconds to wait between retries, waits grow exponentially with jitter and follow the Retry-After header up to this limit. (Default: 30)",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TSURU_RETRY_MAX_WAIT", int(defaultRetryPolicy.MaxWait/time.Second)),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"protected_pools": {
+				Type:        schema.TypeSet,
+				Description: "Pools where apps, jobs, service instances and volumes are protected as if they had `deletion_protection` enabled",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"tsuru_service":                resourceTsuruService(),
 			"tsuru_service_instance_bind":  resourceTsuruServiceInstanceBind(),
 			"tsuru_service_instance_grant": resourceTsuruServiceInstanceGrant(),
 			"tsuru_service_instance":       resourceTsuruServiceInstance(),
+			"tsuru_service_instance_teams": resourceTsuruServiceInstanceTeams(),
 
 			"tsuru_volume_bind": resourceTsuruVolumeBind(),
 			"tsuru_volume":      resourceTsuruVolume(),
 
-			"tsuru_app_autoscale": resourceTsuruApplicationAutoscale(),
-			"tsuru_app_env":       resourceTsuruApplicationEnvironment(),
-			"tsuru_app_unit":      resourceTsuruApplicationUnits(),
-			"tsuru_app_cname":     resourceTsuruApplicationCName(),
-			"tsuru_app_router":    resourceTsuruApplicationRouter(),
-			"tsuru_app_grant":     resourceTsuruApplicationGrant(),
-			"tsuru_app_deploy":    resourceTsuruApplicationDeploy(),
-			"tsuru_app":           resourceTsuruApplication(),
+			"tsuru_app_autoscale":   resourceTsuruApplicationAutoscale(),
+			"tsuru_app_certificate": resourceTsuruApplicationCertificate(),
+			"tsuru_app_env":         resourceTsuruApplicationEnvironment(),
+			"tsuru_app_unit":        resourceTsuruApplicationUnits(),
+			"tsuru_app_cname":       resourceTsuruApplicationCName(),
+			"tsuru_app_cnames":      resourceTsuruApplicationCNames(),
+			"tsuru_app_process":     resourceTsuruApplicationProcess(),
+			"tsuru_app_restart":     resourceTsuruApplicationRestart(),
+			"tsuru_app_router":      resourceTsuruApplicationRouter(),
+			"tsuru_app_grant":       resourceTsuruApplicationGrant(),
+			"tsuru_app_deploy":      resourceTsuruApplicationDeploy(),
+			"tsuru_app_quota":       resourceTsuruApplicationQuota(),
+			"tsuru_app_teams":       resourceTsuruApplicationTeams(),
+			"tsuru_app":             resourceTsuruApplication(),
 
 			"tsuru_certificate_issuer": resourceTsuruCertificateIssuer(),
 
@@ -85,9 +160,16 @@ func Provider() *schema.Provider {
 			"tsuru_cluster_pool":    resourceTsuruClusterPool(),
 			"tsuru_cluster":         resourceTsuruCluster(),
 			"tsuru_token":           resourceTsuruToken(),
+			"tsuru_platform":        resourceTsuruPlatform(),
+			"tsuru_team_quota":      resourceTsuruTeamQuota(),
+			"tsuru_user_quota":      resourceTsuruUserQuota(),
+			"tsuru_event_block":     resourceTsuruEventBlock(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"tsuru_app": dataSourceTsuruApp(),
+			"tsuru_app":              dataSourceTsuruApp(),
+			"tsuru_app_certificates": dataSourceTsuruAppCertificates(),
+			"tsuru_quota":            dataSourceTsuruQuota(),
+			"tsuru_events":           dataSourceTsuruEvents(),
 		},
 	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -102,6 +184,18 @@ type tsuruProvider struct {
 	Token              string
 	TsuruClient        *tsuru.APIClient
 	FullManagementEnvs bool
+	ProtectedPools     []string
+	DefaultTags        []string
+	DefaultMetadata    tsuru.Metadata
+	DefaultTeamOwner   string
+	DefaultPool        string
+	DefaultPlan        string
+
+	httpClient *http.Client
+	retry      retryPolicy
+	catalog    catalogCache
+	apps       appCache
+	locks      targetLocks
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
@@ -160,12 +254,47 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 
 	fullManagementEnvs := d.Get("full_management_of_user_environment_variables").(bool)
 
-	return &tsuruProvider{
+	defaultTags := []string{}
+	for _, tag := range d.Get("default_tags").([]interface{}) {
+		defaultTags = append(defaultTags, tag.(string))
+	}
+
+	defaultMetadata := tsuru.Metadata{}
+	if metadata := metadataFromResourceData(d.Get("default_metadata")); metadata != nil {
+		defaultMetadata = *metadata
+	}
+
+	provider := &tsuruProvider{
 		Host:               host,
 		Token:              token,
 		TsuruClient:        client,
 		FullManagementEnvs: fullManagementEnvs,
-	}, nil
+		ProtectedPools:     setToStringSlice(d.Get("protected_pools").(*schema.Set)),
+		DefaultTags:        defaultTags,
+		DefaultMetadata:    defaultMetadata,
+		DefaultTeamOwner:   d.Get("default_team_owner").(string),
+		DefaultPool:        d.Get("default_pool").(string),
+		DefaultPlan:        d.Get("default_plan").(string),
+		retry: retryPolicy{
+			MaxRetries: d.Get("max_retries").(int),
+			BaseWait:   defaultRetryPolicy.BaseWait,
+			MaxWait:    time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		},
+	}
+
+	httpClient := *cfg.HTTPClient
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpClient.Transport = &appCacheTransport{
+		base:  &retryTransport{base: transport, policy: &provider.retry},
+		cache: &provider.apps,
+	}
+	cfg.HTTPClient = &httpClient
+	provider.httpClient = &httpClient
+
+	return provider, nil
 }
 
 func logTsuruStream(in io.Reader) {
@@ -174,3 +303,75 @@ func logTsuruStream(in io.Reader) {
 		log.Println("[INFO] ", reader.Text())
 	}
 }
+
+// readTsuruStream logs the messages of a tsuru JSON stream and returns the
+// first error reported on it, tsuru answers streamed operations with 200 even
+// when they fail halfway.
+func readTsuruStream(in io.Reader) error {
+	var streamErr error
+	reader := bufio.NewScanner(in)
+	reader.Buffer(make([]byte, 64*1024), 1024*1024)
+	for reader.Scan() {
+		var message struct {
+			Message string
+			Error   string
+		}
+		if err := json.Unmarshal(reader.Bytes(), &message); err != nil {
+			log.Println("[INFO] ", reader.Text())
+			continue
+		}
+		if message.Message != "" {
+			log.Println("[INFO] ", strings.TrimRight(message.Message, "\n"))
+		}
+		if message.Error != "" && streamErr == nil {
+			streamErr = errors.New(message.Error)
+		}
+	}
+	if err := reader.Err(); err != nil && streamErr == nil {
+		streamErr = err
+	}
+	return streamErr
+}
+
+// tsuruRequest sends a request to endpoints not covered by the generated
+// client, responses with status code greater than 299 are returned as errors.
+func tsuruRequest(ctx context.Context, provider *tsuruProvider, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, provider.Host+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	token := provider.Token
+	if token == "" {
+		token = deployToken()
+	}
+	req.Header.Set("Authorization", token)
+
+	resp, err := provider.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		message, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &tsuruRequestError{StatusCode: resp.StatusCode, Message: string(message)}
+	}
+
+	return resp, nil
+}
+
+type tsuruRequestError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *tsuruRequestError) Error() string {
+	return fmt.Sprintf("status code: %d, message: %s", e.StatusCode, e.Message)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// unlimitedQuota is the limit tsuru uses for quotas without restriction.
+const unlimitedQuota = -1
+
+func getAppQuota(ctx context.Context, provider *tsuruProvider, app string) (tsuru_client.Quota, error) {
+	quota, _, err := provider.TsuruClient.AppApi.AppQuotaGet(ctx, app)
+	return quota, err
+}
+
+func changeAppQuota(ctx context.Context, provider *tsuruProvider, app string, limit int, timeout time.Duration) error {
+	return tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.AppApi.AppQuotaChange(ctx, app, float32(limit))
+		return err
+	})
+}
+
+func getTeamQuota(ctx context.Context, provider *tsuruProvider, team string) (tsuru_client.Quota, error) {
+	quota, _, err := provider.TsuruClient.TeamApi.TeamQuotaGet(ctx, team)
+	return tsuru_client.Quota{Inuse: int64(quota.Inuse), Limit: int64(quota.Limit)}, err
+}
+
+func changeTeamQuota(ctx context.Context, provider *tsuruProvider, team string, limit int, timeout time.Duration) error {
+	return tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.TeamApi.TeamQuotaChange(ctx, team, float32(limit))
+		return err
+	})
+}
+
+func getUserQuota(ctx context.Context, provider *tsuruProvider, email string) (tsuru_client.Quota, error) {
+	quota, _, err := provider.TsuruClient.UserApi.UserQuotaGet(ctx, email)
+	return tsuru_client.Quota{Inuse: int64(quota.Inuse), Limit: int64(quota.Limit)}, err
+}
+
+func changeUserQuota(ctx context.Context, provider *tsuruProvider, email string, limit int, timeout time.Duration) error {
+	return tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.UserApi.UserQuotaChange(ctx, email, int32(limit))
+		return err
+	})
+}
+
+func flattenQuota(d *schema.ResourceData, quota tsuru_client.Quota) {
+	d.Set("limit", quota.Limit)
+	d.Set("in_use", quota.Inuse)
+}
@@ -6,11 +6,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
@@ -22,6 +29,15 @@ func resourceTsuruApplication() *schema.Resource {
 		UpdateContext: resourceTsuruApplicationUpdate,
 		ReadContext:   resourceTsuruApplicationRead,
 		DeleteContext: resourceTsuruApplicationDelete,
+		CustomizeDiff: resourceTsuruApplicationCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceTsuruApplicationV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceTsuruApplicationStateUpgradeV0,
+			},
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -30,134 +46,211 @@ func resourceTsuruApplication() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTsuruApplicationImport,
 		},
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Description: "Application name",
-				Required:    true,
-				ForceNew:    true,
-			},
-			"description": {
-				Type:        schema.TypeString,
-				Description: "Application description",
-				Optional:    true,
-			},
-			"platform": {
-				Type:        schema.TypeString,
-				Description: "Platform",
-				Required:    true,
-			},
-			"plan": {
-				Type:        schema.TypeString,
-				Description: "Plan",
-				Required:    true,
-			},
-			"custom_cpu_burst": {
-				Type:        schema.TypeFloat,
-				Description: "CPU burst factory override",
-				Optional:    true,
-			},
-			"team_owner": {
-				Type:        schema.TypeString,
-				Description: "Application owner",
-				Required:    true,
-			},
-			"cluster": {
-				Type:        schema.TypeString,
-				Description: "The name of cluster",
-				Computed:    true,
-			},
-			"pool": {
-				Type:        schema.TypeString,
-				Description: "The name of pool",
-				Required:    true,
-			},
-			"tags": {
-				Type:        schema.TypeList,
-				Description: "Tags",
-				Optional:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		Schema: resourceTsuruApplicationSchema(),
+	}
+}
+
+// appRequestAttributes are the attributes sent on app creation and update
+// the tsuru API may reject.
+var appRequestAttributes = []string{"pool", "plan", "platform", "team_owner", "default_router", "tags", "process.plan", "process.name"}
+
+func resourceTsuruApplicationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Application name",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Application description",
+			Optional:    true,
+		},
+		"platform": {
+			Type:        schema.TypeString,
+			Description: "Platform, optionally pinned to a version (e.g. python:3)",
+			Required:    true,
+		},
+		"platform_version": {
+			Type:        schema.TypeString,
+			Description: "Platform version the app is built with, the one pinned on platform (e.g. 3 on python:3) or, when the app follows the latest platform image, the newest version of the platform on its last deploy. Empty before the first deploy",
+			Computed:    true,
+		},
+		"deploys": {
+			Type:        schema.TypeInt,
+			Description: "Number of deploys of the app",
+			Computed:    true,
+		},
+		"plan": {
+			Type:        schema.TypeString,
+			Description: "Plan, defaults to the provider default_plan on creation",
+			Optional:    true,
+			Computed:    true,
+		},
+		"custom_cpu_burst": {
+			Type:          schema.TypeFloat,
+			Description:   "CPU burst factory override",
+			Optional:      true,
+			Deprecated:    "use plan_override.cpu_burst instead",
+			ConflictsWith: []string{"plan_override"},
+		},
+		"plan_override": {
+			Type:          schema.TypeList,
+			Description:   "Overrides of the plan resources for this app, removing it clears the overrides",
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"custom_cpu_burst"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"memory": {
+						Type:             schema.TypeString,
+						Description:      "Memory limit, in bytes or as a quantity (e.g. 512Mi)",
+						Optional:         true,
+						ValidateFunc:     validateMemoryQuantity,
+						DiffSuppressFunc: suppressEquivalentMemoryQuantity,
+					},
+					"cpu": {
+						Type:             schema.TypeString,
+						Description:      "CPU limit, in units (1.5), millis (1500m) or percent (150%)",
+						Optional:         true,
+						ValidateFunc:     validateCPUQuantity,
+						DiffSuppressFunc: suppressEquivalentCPUQuantity,
+					},
+					"cpu_burst": {
+						Type:         schema.TypeFloat,
+						Description:  "Factor of CPU burst, ie: 1.1 means 10% of burst",
+						Optional:     true,
+						ValidateFunc: validation.FloatAtLeast(1),
+					},
 				},
 			},
-			"metadata": metadataSchema(),
-			"process": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"plan": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"metadata": metadataSchema(),
+		},
+		"team_owner": {
+			Type:        schema.TypeString,
+			Description: "Application owner, defaults to the provider default_team_owner on creation",
+			Optional:    true,
+			Computed:    true,
+		},
+		"cluster": {
+			Type:        schema.TypeString,
+			Description: "The name of cluster",
+			Computed:    true,
+		},
+		"pool": {
+			Type:        schema.TypeString,
+			Description: "The name of pool, defaults to the provider default_pool on creation",
+			Optional:    true,
+			Computed:    true,
+		},
+		"tags": {
+			Type:        schema.TypeList,
+			Description: "Tags",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"metadata":     metadataSchema(),
+		"tags_all":     tagsAllSchema("app"),
+		"metadata_all": metadataAllSchema("app"),
+		"process": {
+			Type:        schema.TypeSet,
+			Description: "Processes of the app, in any order",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"plan": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"metadata": metadataSchema(),
+					"state": {
+						Type:         schema.TypeString,
+						Description:  "State of the units of the process, `started` or `stopped`, overrides the state of the app",
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"started", "stopped"}, false),
 					},
 				},
 			},
+		},
+		"state": {
+			Type:         schema.TypeString,
+			Description:  "State of the units of the app, `started` or `stopped`, apps never deployed or without units keep the configured state",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"started", "stopped"}, false),
+		},
 
-			"default_router": {
-				Type:        schema.TypeString,
-				Description: "Default router at creation of app",
-				Optional:    true,
-			},
-			"restart_on_update": {
-				Type:        schema.TypeBool,
-				Description: "Restart app after applying changes",
-				Optional:    true,
-			},
+		"deletion_protection": {
+			Type:        schema.TypeBool,
+			Description: "Prevent the app from being destroyed, it must be set to false and applied before destroying the app",
+			Optional:    true,
+			Default:     false,
+		},
 
-			"internal_address": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"domain": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"port": {
-							Type:     schema.TypeInt,
-							Computed: true,
-						},
-						"process": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"version": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"protocol": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+		"default_router": {
+			Type:        schema.TypeString,
+			Description: "Default router at creation of app",
+			Optional:    true,
+		},
+		"restart_on_update": {
+			Type:        schema.TypeBool,
+			Description: "Restart app after applying changes",
+			Optional:    true,
+		},
+
+		"internal_address": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"domain": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"port": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"process": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"version": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"protocol": {
+						Type:     schema.TypeString,
+						Computed: true,
 					},
 				},
 			},
+		},
 
-			"router": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"addresses": {
-							Type:     schema.TypeList,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Computed: true,
-						},
-						"options": {
-							Type:     schema.TypeMap,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Computed: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+		"router": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"addresses": {
+						Type:     schema.TypeList,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Computed: true,
+					},
+					"options": {
+						Type:     schema.TypeMap,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
 					},
 				},
 			},
@@ -187,23 +280,50 @@ func metadataSchema() *schema.Schema {
 	}
 }
 
+// tagsAllSchema is the computed tags of the resource with the default_tags of
+// the provider, a change of the defaults alone changes it.
+func tagsAllSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Tags of the %s on tsuru, including the default_tags of the provider", kind),
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// metadataAllSchema is the computed metadata of the resource with the
+// default_metadata of the provider, defaults removed from the provider are
+// deleted from the resource on the next apply.
+func metadataAllSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Metadata of the %s on tsuru, including the default_metadata of the provider", kind),
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"labels": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"annotations": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
 func resourceTsuruApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
 	platform := d.Get("platform").(string)
-	if err := validPlatform(ctx, provider, platform); err != nil {
-		return diag.FromErr(err)
-	}
-
 	pool := d.Get("pool").(string)
-	if err := validPool(ctx, provider, pool); err != nil {
-		return diag.FromErr(err)
-	}
-
 	plan := d.Get("plan").(string)
-	if err := validPlan(ctx, provider, plan); err != nil {
-		return diag.FromErr(err)
-	}
 
 	tags := []string{}
 	for _, item := range d.Get("tags").([]interface{}) {
@@ -222,23 +342,16 @@ func resourceTsuruApplicationCreate(ctx context.Context, d *schema.ResourceData,
 		Plan:      plan,
 		TeamOwner: d.Get("team_owner").(string),
 		Router:    defaultRouter,
-		Tags:      tags,
+		Tags:      mergeDefaultTags(provider, tags),
 	}
 
-	if m, ok := d.GetOk("metadata"); ok {
-		metadata := metadataFromResourceData(m)
-		if metadata != nil {
-			app.Metadata = *metadata
-		}
+	if metadata := mergeDefaultMetadata(provider, metadataFromResourceData(d.Get("metadata"))); metadata != nil {
+		app.Metadata = *metadata
 	}
 
 	if m, ok := d.GetOk("process"); ok {
 		processes := processesFromResourceData(m)
 		if processes != nil {
-			err := validateProcessesOrder(processes)
-			if err != nil {
-				return diag.FromErr(err)
-			}
 			app.Processes = processes
 		}
 	}
@@ -249,11 +362,28 @@ func resourceTsuruApplicationCreate(ctx context.Context, d *schema.ResourceData,
 
 	_, _, err := provider.TsuruClient.AppApi.AppCreate(ctx, app)
 	if err != nil {
-		return diag.Errorf("unable to create app %s: %v", app.Name, err)
+		return tsuruResourceDiagnostics(d, appRequestAttributes, err, "unable to create app %s", app.Name)
 	}
 
 	d.SetId(app.Name)
 
+	// overrides can't be sent on app creation
+	planOverride, err := planOverrideFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if planOverride.Memory != nil || planOverride.Cpumilli != nil || planOverride.CpuBurst != nil {
+		resp, err := provider.TsuruClient.AppApi.AppUpdate(ctx, app.Name, tsuru_client.UpdateApp{
+			Planoverride: planOverride,
+			NoRestart:    true,
+		})
+		if err != nil {
+			return tsuruDiagnostics(err, "unable to set plan override of app %s", app.Name)
+		}
+		defer resp.Body.Close()
+		logTsuruStream(resp.Body)
+	}
+
 	return resourceTsuruApplicationRead(ctx, d, meta)
 }
 
@@ -261,19 +391,14 @@ func resourceTsuruApplicationUpdate(ctx context.Context, d *schema.ResourceData,
 	provider := meta.(*tsuruProvider)
 	name := d.Get("name").(string)
 	platform := d.Get("platform").(string)
-	if err := validPlatform(ctx, provider, platform); err != nil {
-		return diag.FromErr(err)
-	}
-
 	pool := d.Get("pool").(string)
-	if err := validPool(ctx, provider, pool); err != nil {
-		return diag.FromErr(err)
-	}
-
 	plan := d.Get("plan").(string)
-	if err := validPlan(ctx, provider, plan); err != nil {
-		return diag.FromErr(err)
+
+	unlock, err := provider.locks.lock(ctx, appLock(name))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", name, err)
 	}
+	defer unlock()
 
 	tags := []string{}
 	for _, item := range d.Get("tags").([]interface{}) {
@@ -285,32 +410,39 @@ func resourceTsuruApplicationUpdate(ctx context.Context, d *schema.ResourceData,
 		Pool:      pool,
 		Plan:      plan,
 		TeamOwner: d.Get("team_owner").(string),
-		Tags:      tags,
+		Tags:      mergeDefaultTags(provider, tags),
 	}
 
-	if cpu_burst, ok := d.GetOk("custom_cpu_burst"); ok {
-		cpuBurstValue := cpu_burst.(float64)
-		app.Planoverride.CpuBurst = &cpuBurstValue
-	}
+	if d.HasChanges("plan_override", "custom_cpu_burst") {
+		// unset fields are sent as zero, which clears them on tsuru
+		zero := int64(0)
+		zeroCPU := 0
+		zeroBurst := float64(0)
+		app.Planoverride = tsuru_client.PlanOverride{Memory: &zero, Cpumilli: &zeroCPU, CpuBurst: &zeroBurst}
 
-	if d.HasChange("metadata") {
-		old, new := d.GetChange("metadata")
-		oldMetadata := metadataFromResourceData(old)
-		if oldMetadata == nil {
-			oldMetadata = &tsuru_client.Metadata{}
+		planOverride, err := planOverrideFromResourceData(d)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		newMetadata := metadataFromResourceData(new)
-		if newMetadata == nil {
-			newMetadata = &tsuru_client.Metadata{}
+		if planOverride.Memory != nil {
+			app.Planoverride.Memory = planOverride.Memory
 		}
-
-		app.Metadata = tsuru_client.Metadata{
-			Annotations: markRemovedMetadataItemAsDeleted(oldMetadata.Annotations, newMetadata.Annotations),
-			Labels:      markRemovedMetadataItemAsDeleted(oldMetadata.Labels, newMetadata.Labels),
+		if planOverride.Cpumilli != nil {
+			app.Planoverride.Cpumilli = planOverride.Cpumilli
+		}
+		if planOverride.CpuBurst != nil {
+			app.Planoverride.CpuBurst = planOverride.CpuBurst
 		}
+	} else if cpu_burst, ok := d.GetOk("custom_cpu_burst"); ok {
+		cpuBurstValue := cpu_burst.(float64)
+		app.Planoverride.CpuBurst = &cpuBurstValue
 	}
 
-	if d.HasChange("process") {
+	if d.HasChanges("metadata", "metadata_all") {
+		app.Metadata = metadataUpdate(provider, d)
+	}
+
+	if d.HasChange("process") && !sameProcessesIgnoringState(d.GetChange("process")) {
 		old, new := d.GetChange("process")
 		oldProcesses := processesFromResourceData(old)
 		if oldProcesses == nil {
@@ -320,10 +452,6 @@ func resourceTsuruApplicationUpdate(ctx context.Context, d *schema.ResourceData,
 		if newProcesses == nil {
 			newProcesses = []tsuru_client.AppProcess{}
 		}
-		err := validateProcessesOrder(newProcesses)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
 		app.Processes = markRemovedProcessAsDefaultPlan(oldProcesses, newProcesses)
 	}
@@ -341,71 +469,287 @@ func resourceTsuruApplicationUpdate(ctx context.Context, d *schema.ResourceData,
 		app.NoRestart = true
 	}
 
-	resp, err := provider.TsuruClient.AppApi.AppUpdate(ctx, name, app)
-	if err != nil {
-		return diag.Errorf("unable to update app %s: %v", name, err)
+	if d.HasChangesExcept("state", "process", "deletion_protection") || app.Processes != nil {
+		resp, err := provider.TsuruClient.AppApi.AppUpdate(ctx, name, app)
+		if err != nil {
+			return tsuruResourceDiagnostics(d, appRequestAttributes, err, "unable to update app %s", name)
+		}
+
+		defer resp.Body.Close()
+		logTsuruStream(resp.Body)
 	}
 
-	defer resp.Body.Close()
-	logTsuruStream(resp.Body)
+	if err := applyAppState(ctx, provider, d, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceTsuruApplicationRead(ctx, d, meta)
 }
 
-func validateProcessesOrder(processes []tsuru_client.AppProcess) error {
-	for i := 1; i < len(processes); i++ {
-		if processes[i-1].Name > processes[i].Name {
-			return errors.Errorf("please, sort app processes alphabetically")
+// applyAppState starts or stops the app and then the processes with their
+// own state, the processes are applied again when the app state changes.
+func applyAppState(ctx context.Context, provider *tsuruProvider, d *schema.ResourceData, timeout time.Duration) error {
+	name := d.Get("name").(string)
+	oldProcesses, newProcesses := d.GetChange("process")
+	oldStates := processStates(oldProcesses.(*schema.Set))
+	newStates := processStates(newProcesses.(*schema.Set))
+
+	pending := []string{}
+	for process, state := range newStates {
+		if d.HasChange("state") || oldStates[process] != state {
+			pending = append(pending, process)
 		}
 	}
-	return nil
-}
+	sort.Strings(pending)
 
-func resourceTsuruApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	provider := meta.(*tsuruProvider)
-	name := d.Id()
+	state := d.Get("state").(string)
+	if (!d.HasChange("state") || state == "") && len(pending) == 0 {
+		return nil
+	}
 
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, name)
+	app, err := provider.getApp(ctx, name)
 	if err != nil {
-		if isNotFoundError(err) {
-			d.SetId("")
-			return nil
-		}
-		return diag.Errorf("unable to read app %s: %v", app.Name, err)
+		return errors.Errorf("unable to read app %s: %v", name, err)
 	}
-
-	d.Set("name", name)
-	d.Set("platform", app.Platform)
-	d.Set("pool", app.Pool)
-	d.Set("plan", app.Plan.Name)
-	d.Set("team_owner", app.TeamOwner)
-	d.Set("cluster", app.Cluster)
-
-	if app.Plan.Override.CpuBurst != nil {
-		d.Set("custom_cpu_burst", app.Plan.Override.CpuBurst)
+	if app.Deploys == 0 {
+		log.Printf("[DEBUG] app %s was never deployed, state is applied after the first deploy", name)
+		return nil
 	}
 
-	if app.Description != "" {
-		d.Set("description", app.Description)
+	if d.HasChange("state") && state != "" {
+		if err = appLifecycle(ctx, provider, name, appStateAction(state), "", timeout); err != nil {
+			return err
+		}
 	}
 
-	d.Set("tags", app.Tags)
-
-	d.Set("metadata", flattenMetadata(app.Metadata))
-	d.Set("internal_address", flattenInternalAddresses(app.InternalAddresses))
-	d.Set("router", flattenRouters(app.Routers))
-	d.Set("process", flattenProcesses(app.Processes))
+	for _, process := range pending {
+		if err = appLifecycle(ctx, provider, name, appStateAction(newStates[process]), process, timeout); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func resourceTsuruApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	provider := meta.(*tsuruProvider)
-	name := d.Get("name").(string)
-
-	_, err := provider.TsuruClient.AppApi.AppDelete(ctx, name)
-	if err != nil {
-		return diag.Errorf("unable to delete app %s: %v", name, err)
+func appStateAction(state string) string {
+	if state == "stopped" {
+		return "stop"
+	}
+	return "start"
+}
+
+// processStates returns the configured state of the processes, processes
+// without state are omitted.
+func processStates(processes *schema.Set) map[string]string {
+	states := map[string]string{}
+	for _, iface := range processes.List() {
+		process := iface.(map[string]interface{})
+		if state, _ := process["state"].(string); state != "" {
+			states[process["name"].(string)] = state
+		}
+	}
+	return states
+}
+
+func sameProcessesIgnoringState(old, new interface{}) bool {
+	withoutState := func(processes interface{}) map[string]interface{} {
+		result := map[string]interface{}{}
+		for _, iface := range processes.(*schema.Set).List() {
+			process := map[string]interface{}{}
+			for k, v := range iface.(map[string]interface{}) {
+				if k != "state" {
+					process[k] = v
+				}
+			}
+			result[process["name"].(string)] = process
+		}
+		return result
+	}
+
+	return reflect.DeepEqual(withoutState(old), withoutState(new))
+}
+
+// planOverrideFromResourceData returns the configured overrides, fields not
+// configured are nil.
+func planOverrideFromResourceData(d *schema.ResourceData) (tsuru_client.PlanOverride, error) {
+	planOverride := tsuru_client.PlanOverride{}
+
+	if cpuBurst, ok := d.GetOk("custom_cpu_burst"); ok {
+		cpuBurstValue := cpuBurst.(float64)
+		planOverride.CpuBurst = &cpuBurstValue
+	}
+
+	if memory, ok := d.GetOk("plan_override.0.memory"); ok {
+		memoryBytes, err := parseMemoryQuantity(memory.(string))
+		if err != nil {
+			return planOverride, errors.Errorf("invalid plan_override memory %q: %v", memory, err)
+		}
+		planOverride.Memory = &memoryBytes
+	}
+
+	if cpu, ok := d.GetOk("plan_override.0.cpu"); ok {
+		cpuMilli, err := parseCPUQuantity(cpu.(string))
+		if err != nil {
+			return planOverride, err
+		}
+		cpuMilliValue := int(cpuMilli)
+		planOverride.Cpumilli = &cpuMilliValue
+	}
+
+	if cpuBurst, ok := d.GetOk("plan_override.0.cpu_burst"); ok {
+		cpuBurstValue := cpuBurst.(float64)
+		planOverride.CpuBurst = &cpuBurstValue
+	}
+
+	return planOverride, nil
+}
+
+// flattenPlanOverride formats the cpu in the same format of configuredCPU.
+func flattenPlanOverride(planOverride tsuru_client.PlanOverride, configuredCPU string) []interface{} {
+	result := map[string]interface{}{}
+
+	if planOverride.Memory != nil {
+		result["memory"] = memoryBytesToString(*planOverride.Memory)
+	}
+
+	if planOverride.Cpumilli != nil {
+		cpuMilli := int32(*planOverride.Cpumilli)
+		switch cpuFormat(configuredCPU) {
+		case "percent":
+			result["cpu"] = cpuMillisToPercentString(cpuMilli)
+		case "milli":
+			result["cpu"] = cpuMillisToString(cpuMilli)
+		default:
+			result["cpu"] = cpuMillisToUnitString(cpuMilli)
+		}
+	}
+
+	if planOverride.CpuBurst != nil {
+		result["cpu_burst"] = *planOverride.CpuBurst
+	}
+
+	return []interface{}{result}
+}
+
+func resourceTsuruApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	name := d.Id()
+
+	app, err := provider.getApp(ctx, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", name)
+	}
+
+	d.Set("platform_version", appPlatformVersion(ctx, provider, d, &app))
+	d.Set("deploys", app.Deploys)
+	d.Set("name", name)
+	d.Set("platform", app.Platform)
+	d.Set("pool", app.Pool)
+	d.Set("plan", app.Plan.Name)
+	d.Set("team_owner", app.TeamOwner)
+	d.Set("cluster", app.Cluster)
+
+	if len(d.Get("plan_override").([]interface{})) > 0 || app.Plan.Override.Memory != nil || app.Plan.Override.Cpumilli != nil {
+		d.Set("plan_override", flattenPlanOverride(app.Plan.Override, d.Get("plan_override.0.cpu").(string)))
+	} else if app.Plan.Override.CpuBurst != nil {
+		d.Set("custom_cpu_burst", app.Plan.Override.CpuBurst)
+	}
+
+	if app.Description != "" {
+		d.Set("description", app.Description)
+	}
+
+	d.Set("tags", withoutDefaultTags(provider, app.Tags, parseTags(d.Get("tags"))))
+	d.Set("tags_all", app.Tags)
+
+	d.Set("metadata", flattenMetadata(withoutDefaultMetadata(provider, app.Metadata, metadataFromResourceData(d.Get("metadata")))))
+	d.Set("metadata_all", flattenMetadata(app.Metadata))
+	d.Set("internal_address", flattenInternalAddresses(app.InternalAddresses))
+	d.Set("router", flattenRouters(app.Routers))
+	// processes may be managed by tsuru_app_process instead
+	configuredStates := processStates(d.Get("process").(*schema.Set))
+	if d.Get("process").(*schema.Set).Len() > 0 {
+		processes := flattenProcesses(app.Processes)
+		for _, iface := range processes {
+			process := iface.(map[string]interface{})
+			name := process["name"].(string)
+			state, ok := configuredStates[name]
+			if ok && app.Deploys > 0 {
+				if current := unitsState(app.Units, func(p string) bool { return p == name }); current != "empty" {
+					state = current
+				}
+			}
+			process["state"] = state
+		}
+		d.Set("process", processes)
+	}
+
+	// units of processes with their own state don't count for the app state,
+	// without units the state is unknown and the configured one is kept
+	if app.Deploys > 0 {
+		state := unitsState(app.Units, func(p string) bool {
+			_, ok := configuredStates[p]
+			return !ok
+		})
+		if state != "empty" {
+			d.Set("state", state)
+		}
+	}
+
+	return nil
+}
+
+func resourceTsuruApplicationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	provider := meta.(*tsuruProvider)
+	if err := setProviderDefaults(d, provider, "team_owner", "pool", "plan"); err != nil {
+		return err
+	}
+	if err := setTagsAll(d, provider); err != nil {
+		return err
+	}
+	if err := setMetadataAll(d, provider); err != nil {
+		return err
+	}
+
+	if d.HasChange("platform") {
+		// an unpinned platform is resolved to the newest image on apply
+		if d.Id() != "" {
+			var err error
+			if version := pinnedPlatformVersion(d.Get("platform").(string)); d.NewValueKnown("platform") && version != "" {
+				err = d.SetNew("platform_version", version)
+			} else {
+				err = d.SetNewComputed("platform_version")
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if d.NewValueKnown("platform") {
+			if err := validPlatform(ctx, provider, d.Get("platform").(string)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validatePoolPlanTeamDiff(ctx, d, provider)
+}
+
+func resourceTsuruApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	name := d.Get("name").(string)
+
+	if diags := checkDeletionProtection(provider, d, "app", name); diags != nil {
+		return diags
+	}
+
+	_, err := provider.TsuruClient.AppApi.AppDelete(ctx, name)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to delete app %s", name)
 	}
 
 	return nil
@@ -414,19 +758,202 @@ func resourceTsuruApplicationDelete(ctx context.Context, d *schema.ResourceData,
 func resourceTsuruApplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	provider := meta.(*tsuruProvider)
 
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, d.Id())
+	app, err := provider.getApp(ctx, d.Id())
 	if err != nil {
 		return nil, err
 	}
 
 	d.Set("name", app.Name)
+	d.Set("process", flattenProcesses(app.Processes))
 	d.SetId(app.Name)
 
 	return []*schema.ResourceData{d}, nil
 }
 
+// resourceTsuruApplicationV0 is the schema of tsuru_app with the processes
+// in a list, that had to be sorted by name. It is a frozen copy, attributes
+// added to tsuru_app after the version bump must not be added here.
+func resourceTsuruApplicationV0() *schema.Resource {
+	metadataV0 := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"labels": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"annotations": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Application description",
+				Optional:    true,
+			},
+			"platform": {
+				Type:        schema.TypeString,
+				Description: "Platform",
+				Required:    true,
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Description: "Plan",
+				Required:    true,
+			},
+			"custom_cpu_burst": {
+				Type:        schema.TypeFloat,
+				Description: "CPU burst factory override",
+				Optional:    true,
+			},
+			"team_owner": {
+				Type:        schema.TypeString,
+				Description: "Application owner",
+				Required:    true,
+			},
+			"cluster": {
+				Type:        schema.TypeString,
+				Description: "The name of cluster",
+				Computed:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of pool",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Description: "Tags",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metadata": metadataV0(),
+			"process": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"plan": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"metadata": metadataV0(),
+					},
+				},
+			},
+
+			"default_router": {
+				Type:        schema.TypeString,
+				Description: "Default router at creation of app",
+				Optional:    true,
+			},
+			"restart_on_update": {
+				Type:        schema.TypeBool,
+				Description: "Restart app after applying changes",
+				Optional:    true,
+			},
+
+			"internal_address": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"process": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"router": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"addresses": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"options": {
+							Type:     schema.TypeMap,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceTsuruApplicationStateUpgradeV0 moves the process list to a set, both
+// are stored as arrays so the processes are only sorted by name.
+func resourceTsuruApplicationStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	if processes, ok := rawState["process"].([]interface{}); ok {
+		sort.SliceStable(processes, func(i, j int) bool {
+			nameI, _ := processes[i].(map[string]interface{})["name"].(string)
+			nameJ, _ := processes[j].(map[string]interface{})["name"].(string)
+			return nameI < nameJ
+		})
+		rawState["process"] = processes
+	}
+
+	return rawState, nil
+}
+
+// processesFromResourceData returns the processes sorted by name.
 func processesFromResourceData(meta interface{}) []tsuru_client.AppProcess {
-	m := meta.([]interface{})
+	m := meta.(*schema.Set).List()
 
 	if len(m) == 0 {
 		return nil
@@ -461,6 +988,10 @@ func processesFromResourceData(meta interface{}) []tsuru_client.AppProcess {
 		processes = append(processes, process)
 	}
 
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Name < processes[j].Name
+	})
+
 	return processes
 }
 
@@ -496,21 +1027,32 @@ func metadataFromResourceData(meta interface{}) *tsuru_client.Metadata {
 }
 
 func validPlatform(ctx context.Context, provider *tsuruProvider, platform string) error {
-	platforms, _, err := provider.TsuruClient.PlatformApi.PlatformList(ctx)
-	if err != nil {
-		return err
-	}
-
 	platformParts := strings.SplitN(platform, ":", 2)
 	availablePlatforms := []string{}
-	for _, p := range platforms {
-		if p.Disabled {
-			continue
+
+	for _, refresh := range []bool{false, true} {
+		platforms, fresh, err := provider.listPlatforms(ctx, refresh)
+		if err != nil {
+			return err
 		}
 
-		availablePlatforms = append(availablePlatforms, p.Name)
-		if p.Name == platformParts[0] {
-			return nil
+		availablePlatforms = []string{}
+		for _, p := range platforms {
+			if p.Disabled {
+				continue
+			}
+
+			availablePlatforms = append(availablePlatforms, p.Name)
+			if p.Name == platformParts[0] {
+				if len(platformParts) == 1 {
+					return nil
+				}
+				return validPlatformVersion(ctx, provider, platformParts[0], platformParts[1])
+			}
+		}
+
+		if fresh {
+			break
 		}
 	}
 	plaformList := strings.Join(availablePlatforms, ",")
@@ -518,38 +1060,202 @@ func validPlatform(ctx context.Context, provider *tsuruProvider, platform string
 	return errors.Errorf("invalid platform: %s available platforms are [%s]", platform, plaformList)
 }
 
-func validPool(ctx context.Context, provider *tsuruProvider, pool string) error {
-	pools, _, err := provider.TsuruClient.PoolApi.PoolList(ctx)
+func validPlatformVersion(ctx context.Context, provider *tsuruProvider, name, version string) error {
+	if version == "" || version == "latest" {
+		return nil
+	}
+
+	info, _, err := provider.TsuruClient.PlatformApi.PlatformInfo(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	availablePools := []string{}
-	for _, p := range pools {
-		availablePools = append(availablePools, p.Name)
-		if p.Name == pool {
-			return nil
+	versions := platformVersions(info.Images)
+	wanted, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err == nil {
+		for _, v := range versions {
+			if v == wanted {
+				return nil
+			}
 		}
 	}
-	poolList := strings.Join(availablePools, ",")
 
-	return errors.Errorf("invalid pool: %s available pools are [%s]", pool, poolList)
+	availableVersions := []string{}
+	for _, v := range versions {
+		availableVersions = append(availableVersions, strconv.Itoa(v))
+	}
+	versionList := strings.Join(availableVersions, ",")
+
+	return errors.Errorf("invalid platform version: %s:%s available versions of %s are [%s]", name, version, name, versionList)
 }
 
-func validPlan(ctx context.Context, provider *tsuruProvider, plan string) error {
-	plans, _, err := provider.TsuruClient.PlanApi.PlanList(ctx)
+// platformVersions returns the versions found on the tags of the platform
+// images (e.g. tsuru/python:v3), tsuru ignores the registry part when matching
+// an app to a platform version.
+func platformVersions(images []string) []int {
+	seen := map[int]bool{}
+	versions := []int{}
+
+	for _, image := range images {
+		idx := strings.LastIndex(image, ":")
+		if idx < 0 || strings.Contains(image[idx:], "/") {
+			continue
+		}
+
+		version, err := strconv.Atoi(strings.TrimPrefix(image[idx+1:], "v"))
+		if err != nil || seen[version] {
+			continue
+		}
+
+		seen[version] = true
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+	return versions
+}
+
+// appPlatformVersion returns the platform version app is built with. An app
+// following the latest platform image is built with the newest image on each
+// deploy, tsuru does not keep which one it was, so the newest version is
+// resolved when a deploy or a platform change is seen and kept until then.
+func appPlatformVersion(ctx context.Context, provider *tsuruProvider, d *schema.ResourceData, app *tsuru_client.App) string {
+	if version := pinnedPlatformVersion(app.Platform); version != "" {
+		return version
+	}
+	if app.Deploys == 0 {
+		return ""
+	}
+
+	current := d.Get("platform_version").(string)
+	unchanged := !d.HasChange("platform") && d.Get("platform").(string) == app.Platform
+	if current != "" && unchanged && int64(d.Get("deploys").(int)) == app.Deploys {
+		return current
+	}
+
+	name := strings.SplitN(app.Platform, ":", 2)[0]
+	info, _, err := provider.TsuruClient.PlatformApi.PlatformInfo(ctx, name)
 	if err != nil {
-		return err
+		log.Printf("[WARN] unable to resolve version of platform %s: %v", name, err)
+		return current
+	}
+
+	versions := platformVersions(info.Images)
+	if len(versions) == 0 {
+		return current
+	}
+	return strconv.Itoa(versions[len(versions)-1])
+}
+
+// pinnedPlatformVersion returns the version pinned on the app platform, like
+// 3 on python:3, or "" when the app follows the latest platform image.
+func pinnedPlatformVersion(platform string) string {
+	platformParts := strings.SplitN(platform, ":", 2)
+	if len(platformParts) == 2 && platformParts[1] != "" && platformParts[1] != "latest" {
+		return strings.TrimPrefix(platformParts[1], "v")
 	}
+	return ""
+}
 
+func validPool(ctx context.Context, provider *tsuruProvider, pool string) error {
+	_, err := findPool(ctx, provider, pool)
+	return err
+}
+
+func findPool(ctx context.Context, provider *tsuruProvider, pool string) (*tsuru_client.Pool, error) {
+	availablePools := []string{}
+
+	for _, refresh := range []bool{false, true} {
+		pools, fresh, err := provider.listPools(ctx, refresh)
+		if err != nil {
+			return nil, err
+		}
+
+		availablePools = []string{}
+		for i, p := range pools {
+			availablePools = append(availablePools, p.Name)
+			if p.Name == pool {
+				return &pools[i], nil
+			}
+		}
+
+		if fresh {
+			break
+		}
+	}
+	poolList := strings.Join(availablePools, ",")
+
+	return nil, errors.Errorf("invalid pool: %s available pools are [%s]", pool, poolList)
+}
+
+func validPlan(ctx context.Context, provider *tsuruProvider, plan string) error {
 	availablePlans := []string{}
-	for _, p := range plans {
-		availablePlans = append(availablePlans, p.Name)
-		if p.Name == plan {
-			return nil
+
+	for _, refresh := range []bool{false, true} {
+		plans, fresh, err := provider.listPlans(ctx, refresh)
+		if err != nil {
+			return err
+		}
+
+		availablePlans = []string{}
+		for _, p := range plans {
+			availablePlans = append(availablePlans, p.Name)
+			if p.Name == plan {
+				return nil
+			}
+		}
+
+		if fresh {
+			break
 		}
 	}
 	plansList := strings.Join(availablePlans, ",")
 
 	return errors.Errorf("invalid plan: %s available plans are [%s]", plan, plansList)
 }
+
+// validPoolConstraints checks the team and the plan against the values the
+// pool constraints allow, pools without a constraint allow any value.
+func validPoolConstraints(pool *tsuru_client.Pool, team, plan string) error {
+	if allowed := pool.Allowed["team"]; team != "" && len(allowed) > 0 && !slices.Contains(allowed, team) {
+		return errors.Errorf("team %s is not allowed in pool %s, allowed teams are [%s]", team, pool.Name, strings.Join(allowed, ","))
+	}
+
+	if allowed := pool.Allowed["plan"]; plan != "" && len(allowed) > 0 && !slices.Contains(allowed, plan) {
+		return errors.Errorf("plan %s is not allowed in pool %s, allowed plans are [%s]", plan, pool.Name, strings.Join(allowed, ","))
+	}
+
+	return nil
+}
+
+// validatePoolPlanTeamDiff checks the planned pool, plan and team_owner of
+// apps and jobs, values not known yet are checked on apply.
+func validatePoolPlanTeamDiff(ctx context.Context, d *schema.ResourceDiff, provider *tsuruProvider) error {
+	if d.Id() != "" && !d.HasChanges("pool", "plan", "team_owner") {
+		return nil
+	}
+
+	plan := ""
+	if d.NewValueKnown("plan") {
+		plan = d.Get("plan").(string)
+		if err := validPlan(ctx, provider, plan); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("pool") {
+		return nil
+	}
+
+	pool, err := findPool(ctx, provider, d.Get("pool").(string))
+	if err != nil {
+		return err
+	}
+
+	team := ""
+	if d.NewValueKnown("team_owner") {
+		team = d.Get("team_owner").(string)
+	}
+
+	return validPoolConstraints(pool, team, plan)
+}
@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
@@ -28,6 +27,7 @@ func resourceTsuruApplicationAutoscale() *schema.Resource {
 		ReadContext:   resourceTsuruApplicationAutoscaleRead,
 		UpdateContext: resourceTsuruApplicationAutoscaleSet,
 		DeleteContext: resourceTsuruApplicationAutoscaleDelete,
+		CustomizeDiff: resourceTsuruApplicationAutoscaleCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -158,18 +158,39 @@ func resourceTsuruApplicationAutoscale() *schema.Resource {
 	}
 }
 
+func resourceTsuruApplicationAutoscaleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("max_units") {
+		return nil
+	}
+
+	for _, key := range []string{"app", "process", "max_units"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	provider := meta.(*tsuruProvider)
+	return checkAppUnitQuota(ctx, provider, d.Get("app").(string), d.Get("process").(string), d.Get("max_units").(int))
+}
+
 func resourceTsuruApplicationAutoscaleSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
 	app := d.Get("app").(string)
 
-	appInfo, _, err := provider.TsuruClient.AppApi.AppGet(ctx, app)
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	appInfo, err := provider.getApp(ctx, app)
 	if err != nil {
 		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("Unable to read app %s: %v", app, err)
+		return tsuruDiagnostics(err, "Unable to read app %s", app)
 	}
 
 	if appInfo.Deploys == 0 {
@@ -220,21 +241,12 @@ func resourceTsuruApplicationAutoscaleSet(ctx context.Context, d *schema.Resourc
 		autoscale.Behavior.ScaleDown = scaleDown
 	}
 
-	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err = provider.TsuruClient.AppApi.AutoScaleAdd(ctx, app, autoscale)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(errors.Errorf("Unable to create autoscale %s %s: %v", app, process, err))
-		}
-		return nil
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := provider.TsuruClient.AppApi.AutoScaleAdd(ctx, app, autoscale)
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to create autoscale of process %s of app %s", process, app)
 	}
 
 	d.SetId(createID([]string{app, process}))
@@ -261,11 +273,11 @@ func resourceTsuruApplicationAutoscaleRead(ctx context.Context, d *schema.Resour
 
 	_, proposed := d.GetChange("scale_down")
 	// autoscale info reflects near realtime
-	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err = tsuruRetryWhen(ctx, provider, d.Timeout(schema.TimeoutCreate), isAutoscaleNotFoundError, func() error {
 		retryCount++
 		autoscales, _, err := provider.TsuruClient.AppApi.AutoScaleInfo(ctx, app)
 		if err != nil {
-			return resource.NonRetryableError(errors.Wrapf(err, "unable to read autoscale %s %s", app, process))
+			return err
 		}
 
 		for _, autoscale := range autoscales {
@@ -294,11 +306,11 @@ func resourceTsuruApplicationAutoscaleRead(ctx context.Context, d *schema.Resour
 		}
 
 		if retryCount >= maxRetries {
-			return resource.NonRetryableError(&MaxRetriesError{Message: fmt.Sprintf("Unable to read autoscale for %s::%s (after %d retries)", app, process, maxRetries)})
+			return &MaxRetriesError{Message: fmt.Sprintf("Unable to read autoscale for %s::%s (after %d retries)", app, process, maxRetries)}
 		}
 
 		log.Print("[INFO] no autoscales found, trying again")
-		return resource.RetryableError(fmt.Errorf("unable to read autoscale for %s::%s: process not found", app, process))
+		return errAutoscaleNotFound
 	})
 
 	if err != nil {
@@ -313,33 +325,38 @@ func resourceTsuruApplicationAutoscaleRead(ctx context.Context, d *schema.Resour
 				},
 			}
 		}
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to read autoscale of process %s of app %s", process, app)
 	}
 
 	return nil
 }
 
+// errAutoscaleNotFound is returned while the autoscale of the process is not
+// listed yet, autoscale info reflects the cluster in near realtime.
+var errAutoscaleNotFound = errors.New("autoscale not found")
+
+func isAutoscaleNotFoundError(err error) bool {
+	return errors.Is(err, errAutoscaleNotFound)
+}
+
 func resourceTsuruApplicationAutoscaleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
 	app := d.Get("app").(string)
 	process := d.Get("process").(string)
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
 		_, err := provider.TsuruClient.AppApi.AutoScaleRemove(ctx, app, process)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(errors.Errorf("Unable to remove autoscale %s %s: %v", app, process, err))
-		}
-		return nil
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to remove autoscale of process %s of app %s", process, app)
 	}
 
 	return nil
@@ -45,6 +45,10 @@ func TestAccResourceTsuruAppAutoscalePercentage(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -101,6 +105,39 @@ func TestAccResourceTsuruAppAutoscalePercentage(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruAppAutoscaleQuotaExceeded(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &tsuru.App{
+			Name:  c.Param("name"),
+			Units: []tsuru.Unit{{Processname: "web"}, {Processname: "worker"}},
+		})
+	})
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: 8, Inuse: 2})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruAppAutoscale_percentage(),
+				ExpectError: regexp.MustCompile("10 units of process web exceed the unit quota of app app01: limit is 8, 1 units in use by other processes"),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruAppAutoscale_percentage() string {
 	return `
 	resource "tsuru_app_autoscale" "autoscale" {
@@ -140,6 +177,10 @@ func TestAccResourceTsuruAppAutoscaleNumber(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -232,6 +273,10 @@ func TestAccResourceTsuruAppAutoscaleMilli(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -324,6 +369,10 @@ func TestAccResourceTsuruAppAutoscaleWithSchedules(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -444,6 +493,10 @@ func TestAccResourceTsuruAppAutoscaleWithPrometheus(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -612,6 +665,10 @@ func TestAccResourceTsuruAppAutoscaleWithoutCPU(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -745,6 +802,10 @@ func TestAccResourceTsuruAppAutoscaleScaleDown(t *testing.T) {
 
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: unlimitedQuota})
+	})
+
 	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
 		if iterationCount == 1 {
 			return c.JSON(http.StatusOK, []tsuru.AutoScaleSpec{{
@@ -0,0 +1,276 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceTsuruApplicationCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Application Certificate, a custom TLS certificate for an application CNAME",
+		CreateContext: resourceTsuruApplicationCertificateSet,
+		ReadContext:   resourceTsuruApplicationCertificateRead,
+		UpdateContext: resourceTsuruApplicationCertificateSet,
+		DeleteContext: resourceTsuruApplicationCertificateDelete,
+		CustomizeDiff: resourceTsuruApplicationCertificateCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cname": {
+				Type:        schema.TypeString,
+				Description: "Application CNAME served with the certificate",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded certificate, optionally followed by the intermediate certificates",
+				Required:    true,
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded private key of the certificate",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Description: "Expiration date of the certificate",
+				Computed:    true,
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Description: "Issuer of the certificate",
+				Computed:    true,
+			},
+			"sans": {
+				Type:        schema.TypeList,
+				Description: "DNS names of the certificate",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"cname", "certificate", "private_key"} {
+		if !d.NewValueKnown(key) {
+			for _, computed := range []string{"not_after", "issuer", "sans"} {
+				if err := d.SetNewComputed(computed); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	certificate, err := parseAppCertificate(d.Get("cname").(string), d.Get("certificate").(string), d.Get("private_key").(string))
+	if err != nil {
+		return err
+	}
+
+	if !d.HasChange("certificate") {
+		return nil
+	}
+
+	for key, value := range flattenCertificateInfo(certificate) {
+		if err = d.SetNew(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceTsuruApplicationCertificateSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+	cname := d.Get("cname").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	values := url.Values{}
+	values.Set("cname", cname)
+	values.Set("certificate", d.Get("certificate").(string))
+	values.Set("key", d.Get("private_key").(string))
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		resp, err := tsuruRequest(ctx, provider, http.MethodPut, "/1.0/apps/"+url.PathEscape(app)+"/certificate", "application/x-www-form-urlencoded", strings.NewReader(values.Encode()))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	})
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to set certificate of %s on app %s", cname, app)
+	}
+
+	d.SetId(createID([]string{app, cname}))
+
+	return resourceTsuruApplicationCertificateRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	parts, err := IDtoParts(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	app := parts[0]
+	cname := parts[1]
+
+	certificates, _, err := provider.TsuruClient.AppApi.AppGetCertificates(ctx, app)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read certificates of app %s", app)
+	}
+
+	var current string
+	for _, router := range certificates.Routers {
+		if c, ok := router.Cnames[cname]; ok && c.Certificate != "" && c.Issuer == "" {
+			current = c.Certificate
+			break
+		}
+	}
+
+	if current == "" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("app", app)
+	d.Set("cname", cname)
+
+	// keep the configured PEM while tsuru serves the same certificate, it
+	// may return it with a different encoding
+	if !sameCertificate(d.Get("certificate").(string), current) {
+		d.Set("certificate", current)
+	}
+
+	leaf, err := parseLeafCertificate(current)
+	if err != nil {
+		return diag.Errorf("unable to parse certificate of %s on app %s: %v", cname, app, err)
+	}
+	for key, value := range flattenCertificateInfo(leaf) {
+		d.Set(key, value)
+	}
+
+	return nil
+}
+
+func resourceTsuruApplicationCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+	cname := d.Get("cname").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	path := "/1.0/apps/" + url.PathEscape(app) + "/certificate?" + url.Values{"cname": {cname}}.Encode()
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
+		resp, err := tsuruRequest(ctx, provider, http.MethodDelete, path, "", nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	})
+	if err != nil && !isNotFoundError(err) {
+		return tsuruDiagnostics(err, "unable to unset certificate of %s on app %s", cname, app)
+	}
+
+	return nil
+}
+
+// parseAppCertificate checks the certificate is valid for cname and matches
+// the private key, returning the leaf certificate.
+func parseAppCertificate(cname, certificate, privateKey string) (*x509.Certificate, error) {
+	keyPair, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	if err != nil {
+		return nil, errors.Errorf("invalid certificate or private key: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, errors.Errorf("invalid certificate: %v", err)
+	}
+
+	if err = leaf.VerifyHostname(cname); err != nil {
+		return nil, errors.Errorf("cname %s is not in the certificate SANs [%s]", cname, strings.Join(leaf.DNSNames, ","))
+	}
+
+	return leaf, nil
+}
+
+func parseLeafCertificate(certificate string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func sameCertificate(a, b string) bool {
+	leafA, err := parseLeafCertificate(a)
+	if err != nil {
+		return false
+	}
+	leafB, err := parseLeafCertificate(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(leafA.Raw, leafB.Raw)
+}
+
+func flattenCertificateInfo(certificate *x509.Certificate) map[string]interface{} {
+	sans := []interface{}{}
+	for _, name := range certificate.DNSNames {
+		sans = append(sans, name)
+	}
+
+	return map[string]interface{}{
+		"not_after": certificate.NotAfter.UTC().Format(time.RFC3339),
+		"issuer":    certificate.Issuer.String(),
+		"sans":      sans,
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppCertificate(t *testing.T) {
+	fakeServer := echo.New()
+
+	certificate, privateKey := testCertificate(t, "*.app.tsuru.io")
+	otherCertificate, otherPrivateKey := testCertificate(t, "myhost.app.tsuru.io")
+
+	current := ""
+	fakeServer.PUT("/1.0/apps/:app/certificate", func(c echo.Context) error {
+		assert.Equal(t, "app01", c.Param("app"))
+		assert.Equal(t, "myhost.app.tsuru.io", c.FormValue("cname"))
+		assert.Contains(t, []string{certificate, otherCertificate}, c.FormValue("certificate"))
+		assert.Contains(t, []string{privateKey, otherPrivateKey}, c.FormValue("key"))
+		current = c.FormValue("certificate")
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.GET("/1.24/apps/:app/certificate", func(c echo.Context) error {
+		cnames := map[string]tsuru.AppCertificatesCnames{
+			"other.app.tsuru.io": {Issuer: "letsencrypt"},
+		}
+		if current != "" {
+			cnames["myhost.app.tsuru.io"] = tsuru.AppCertificatesCnames{Certificate: current}
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCertificates{
+			Routers: map[string]tsuru.AppCertificatesRouters{
+				"ingress": {Cnames: cnames},
+			},
+		})
+	})
+
+	fakeServer.DELETE("/1.0/apps/:app/certificate", func(c echo.Context) error {
+		assert.Equal(t, "myhost.app.tsuru.io", c.QueryParam("cname"))
+		current = ""
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_certificate.certificate"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Empty(t, current)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppCertificate_basic(certificate, privateKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "cname", "myhost.app.tsuru.io"),
+					resource.TestCheckResourceAttr(resourceName, "issuer", "CN=test-ca"),
+					resource.TestCheckResourceAttr(resourceName, "sans.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "sans.0", "*.app.tsuru.io"),
+					resource.TestCheckResourceAttrSet(resourceName, "not_after"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruAppCertificate_basic(otherCertificate, otherPrivateKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "sans.0", "myhost.app.tsuru.io"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "app01::myhost.app.tsuru.io",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruAppCertificate_invalid(t *testing.T) {
+	certificate, privateKey := testCertificate(t, "myhost.app.tsuru.io")
+	_, otherPrivateKey := testCertificate(t, "myhost.app.tsuru.io")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruAppCertificate_cname("other.app.tsuru.io", certificate, privateKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`cname other.app.tsuru.io is not in the certificate SANs \[myhost.app.tsuru.io\]`),
+			},
+			{
+				Config:      testAccResourceTsuruAppCertificate_cname("myhost.app.tsuru.io", certificate, otherPrivateKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid certificate or private key: tls: private key does not match public key`),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppCertificate_basic(certificate, privateKey string) string {
+	return testAccResourceTsuruAppCertificate_cname("myhost.app.tsuru.io", certificate, privateKey)
+}
+
+func testAccResourceTsuruAppCertificate_cname(cname, certificate, privateKey string) string {
+	return fmt.Sprintf(`
+resource "tsuru_app_certificate" "certificate" {
+  app         = "app01"
+  cname       = %q
+  certificate = <<EOT
+%sEOT
+  private_key = <<EOT
+%sEOT
+}
+`, cname, certificate, privateKey)
+}
+
+// testCertificate returns a PEM certificate for dnsName, signed by a
+// throwaway CA, and its private key.
+func testCertificate(t *testing.T, dnsName string) (string, string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, leaf, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -55,25 +53,22 @@ func resourceTsuruApplicationCNameCreate(ctx context.Context, d *schema.Resource
 		Cname: []string{hostname},
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err := provider.TsuruClient.AppApi.AppCnameAdd(ctx, app, cname)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
 
-		d.SetId(createID([]string{app, hostname}))
-		return nil
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := provider.TsuruClient.AppApi.AppCnameAdd(ctx, app, cname)
+		return err
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to add cname %s to app %s", hostname, app)
 	}
+
+	d.SetId(createID([]string{app, hostname}))
+
 	return resourceTsuruApplicationCNameRead(ctx, d, meta)
 }
 
@@ -87,23 +82,24 @@ func resourceTsuruApplicationCNameRead(ctx context.Context, d *schema.ResourceDa
 	appName := parts[0]
 	hostname := parts[1]
 
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, appName)
+	app, err := provider.getApp(ctx, appName)
 	if err != nil {
 		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to get app %s: %v", appName, err)
+		return tsuruDiagnostics(err, "unable to get app %s", appName)
 	}
 
 	for _, name := range app.Cname {
 		if hostname == name {
 			d.Set("app", appName)
 			d.Set("hostname", name)
-			break
+			return nil
 		}
 	}
 
+	d.SetId("")
 	return nil
 }
 
@@ -116,23 +112,18 @@ func resourceTsuruApplicationCNameDelete(ctx context.Context, d *schema.Resource
 		Cname: []string{hostname},
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
-		_, err := provider.TsuruClient.AppApi.AppCnameDelete(ctx, app, cname)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
 
-		return nil
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
+		_, err := provider.TsuruClient.AppApi.AppCnameDelete(ctx, app, cname)
+		return err
 	})
-
 	if err != nil {
-		return diag.Errorf("unable to delete cname: %v", err)
+		return tsuruDiagnostics(err, "unable to remove cname %s from app %s", hostname, app)
 	}
 
 	return nil
@@ -8,9 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -95,3 +99,98 @@ func testAccResourceTsuruAppCName_basic() string {
 	}
 `
 }
+
+func TestAccResourceTsuruAppCName_concurrent(t *testing.T) {
+	fakeServer := echo.New()
+
+	var mu sync.Mutex
+	cnames := []string{}
+	inflight := 0
+	locked := 0
+
+	// tsuru fails concurrent actions on the same app with event locked
+	lockApp := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if inflight > 0 {
+			locked++
+			return false
+		}
+		inflight++
+		return true
+	}
+	unlockApp := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		inflight--
+	}
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.JSON(http.StatusOK, &tsuru.App{Name: c.Param("name"), Cname: cnames})
+	})
+
+	fakeServer.POST("/1.0/apps/:app/cname", func(c echo.Context) error {
+		if !lockApp() {
+			return c.String(http.StatusConflict, "event locked: app.update.cname.add")
+		}
+		defer unlockApp()
+
+		cname := tsuru.AppCName{}
+		c.Bind(&cname)
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		cnames = append(cnames, cname.Cname...)
+		mu.Unlock()
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.DELETE("/1.0/apps/:app/cname", func(c echo.Context) error {
+		if !lockApp() {
+			return c.String(http.StatusConflict, "event locked: app.update.cname.remove")
+		}
+		defer unlockApp()
+
+		mu.Lock()
+		cnames = []string{}
+		mu.Unlock()
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppCName_concurrent(),
+				Check: func(s *terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+					sort.Strings(cnames)
+					assert.Equal(t, []string{"host1.app.tsuru.io", "host2.app.tsuru.io", "host3.app.tsuru.io", "host4.app.tsuru.io"}, cnames)
+					assert.Equal(t, 0, locked)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppCName_concurrent() string {
+	return `
+	resource "tsuru_app_cname" "cname" {
+		count    = 4
+		app      = "app01"
+		hostname = "host${count.index + 1}.app.tsuru.io"
+	}
+`
+}
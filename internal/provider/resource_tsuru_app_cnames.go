@@ -0,0 +1,328 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// cnameMoveTimeout bounds how long a new cname waits to be released by
+// another app, e.g. when it moves between apps on the same apply.
+const cnameMoveTimeout = 5 * time.Minute
+
+func resourceTsuruApplicationCNames() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Application CNames, authoritative list of the CNAMEs of an app, cnames added outside of it are removed",
+		CreateContext: resourceTsuruApplicationCNamesSet,
+		ReadContext:   resourceTsuruApplicationCNamesRead,
+		UpdateContext: resourceTsuruApplicationCNamesSet,
+		DeleteContext: resourceTsuruApplicationCNamesDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cnames": {
+				Type:        schema.TypeSet,
+				Description: "CNAMEs of the application, a cname still used by another app is retried for a few minutes so it can move between apps on a single apply",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_router": {
+				Type:        schema.TypeBool,
+				Description: "Wait until each router of the app is ready and reports the cnames on its addresses, routers without status or addresses support are only waited for what they report",
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for_certificates": {
+				Type:        schema.TypeBool,
+				Description: "Wait until the certificates of the cnames with a certificate issuer are ready on the routers with TLS support",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationCNamesSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Get("app").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	desired := setToStringSlice(d.Get("cnames").(*schema.Set))
+	add, remove := diffStringSets(app.Cname, desired)
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	// new names are added first, so the app keeps answering on the old ones
+	// until the new ones are in place
+	if len(add) > 0 {
+		moveTimeout := timeout
+		if moveTimeout > cnameMoveTimeout {
+			moveTimeout = cnameMoveTimeout
+		}
+		err = tsuruRetryWhen(ctx, provider, moveTimeout, isCNameMovingError, func() error {
+			_, err := provider.TsuruClient.AppApi.AppCnameAdd(ctx, appName, tsuru_client.AppCName{Cname: add})
+			return err
+		})
+		if err != nil {
+			return tsuruDiagnostics(err, "unable to add cnames %s to app %s", strings.Join(add, ","), appName)
+		}
+	}
+
+	d.SetId(appName)
+
+	if len(remove) > 0 {
+		if err = removeAppCNames(ctx, provider, appName, remove, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// other resources of the app don't wait for the routers
+	unlock()
+
+	if d.Get("wait_for_router").(bool) {
+		if err = waitAppRoutersReady(ctx, provider, appName, desired, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("wait_for_certificates").(bool) {
+		if err = waitAppCertificatesReady(ctx, provider, appName, desired, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTsuruApplicationCNamesRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationCNamesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Id()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	d.Set("app", appName)
+	d.Set("cnames", app.Cname)
+
+	return nil
+}
+
+func resourceTsuruApplicationCNamesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Id()
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	if len(app.Cname) == 0 {
+		return nil
+	}
+
+	if err = removeAppCNames(ctx, provider, appName, app.Cname, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func removeAppCNames(ctx context.Context, provider *tsuruProvider, app string, cnames []string, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.AppApi.AppCnameDelete(ctx, app, tsuru_client.AppCName{Cname: cnames})
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to remove cnames %s from app %s", strings.Join(cnames, ","), app)
+	}
+	return nil
+}
+
+// isCNameMovingError returns whether adding a cname failed because it is
+// still on the app it is moving from, or on event locked.
+func isCNameMovingError(err error) bool {
+	var apiError tsuru_client.GenericOpenAPIError
+	if errors.As(err, &apiError) && strings.Contains(string(apiError.Body()), "already exists for app ") {
+		return true
+	}
+	return isEventLockedError(err)
+}
+
+type appRouterStatus struct {
+	Name         string   `json:"name"`
+	Status       string   `json:"status"`
+	StatusDetail string   `json:"status-detail"`
+	Addresses    []string `json:"addresses"`
+}
+
+// appRouterStatuses reads the status and the addresses of the app routers,
+// they are not exposed by the generated client.
+func appRouterStatuses(ctx context.Context, provider *tsuruProvider, app string) ([]appRouterStatus, error) {
+	resp, err := tsuruRequest(ctx, provider, http.MethodGet, "/1.0/apps/"+url.PathEscape(app), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Routers []appRouterStatus `json:"routers"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Errorf("unable to decode app %s: %v", app, err)
+	}
+
+	return result.Routers, nil
+}
+
+// routerHosts returns the hosts of the addresses a router reports, they may
+// come with a scheme or a path.
+func routerHosts(addresses []string) map[string]bool {
+	hosts := map[string]bool{}
+	for _, address := range addresses {
+		if u, err := url.Parse(address); err == nil && u.Host != "" {
+			address = u.Host
+		}
+		host, _, _ := strings.Cut(address, "/")
+		hosts[host] = true
+	}
+	return hosts
+}
+
+// waitAppRoutersReady waits until every router of the app reports each of
+// cnames as ready: the router is ready and lists the cname on its addresses.
+// Routers without status support report an empty status, routers without
+// addresses support report none, only what they report is waited for.
+func waitAppRoutersReady(ctx context.Context, provider *tsuruProvider, app string, cnames []string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		routers, err := appRouterStatuses(ctx, provider, app)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		pending := []string{}
+		for _, router := range routers {
+			if router.Status != "" && router.Status != "ready" {
+				pending = append(pending, fmt.Sprintf("router %s is %s: %s", router.Name, router.Status, router.StatusDetail))
+				continue
+			}
+			if len(router.Addresses) == 0 {
+				continue
+			}
+			hosts := routerHosts(router.Addresses)
+			for _, cname := range cnames {
+				if !hosts[cname] {
+					pending = append(pending, fmt.Sprintf("%s not on router %s", cname, router.Name))
+				}
+			}
+		}
+
+		if len(pending) > 0 {
+			sort.Strings(pending)
+			return resource.RetryableError(errors.Errorf("cnames of app %s not ready yet: %s", app, strings.Join(pending, ", ")))
+		}
+
+		return nil
+	})
+}
+
+// waitAppCertificatesReady waits for the certificates of the cnames issued by
+// tsuru. Only routers with TLS support are listed by tsuru, a cname is
+// pending until each of them lists it. The certificates are read straight
+// from tsuru and the app is dropped from the cache on every attempt, so
+// neither the polling nor the reads after it see a stale app.
+func waitAppCertificatesReady(ctx context.Context, provider *tsuruProvider, app string, cnames []string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		provider.apps.invalidate(app)
+
+		certificates, _, err := provider.TsuruClient.AppApi.AppGetCertificates(ctx, app)
+		if err != nil {
+			if isNoTLSRouterError(err) {
+				log.Printf("[DEBUG] app %s has no router with TLS support, not waiting for certificates", app)
+				return nil
+			}
+			return resource.NonRetryableError(errors.Errorf("unable to read certificates of app %s: %v", app, err))
+		}
+
+		pending := []string{}
+		for routerName, router := range certificates.Routers {
+			for _, cname := range cnames {
+				certificate, ok := router.Cnames[cname]
+				if !ok {
+					pending = append(pending, cname+" not listed on router "+routerName)
+				} else if certificate.Issuer != "" && certificate.Certificate == "" {
+					pending = append(pending, cname+" on router "+routerName)
+				}
+			}
+		}
+
+		if len(pending) > 0 {
+			sort.Strings(pending)
+			return resource.RetryableError(errors.Errorf("certificates of app %s not ready yet: %s", app, strings.Join(pending, ", ")))
+		}
+
+		return nil
+	})
+}
+
+// isNoTLSRouterError returns whether tsuru refused to list the certificates
+// of an app because none of its routers supports TLS.
+func isNoTLSRouterError(err error) bool {
+	apiErr, ok := parseTsuruAPIError(err)
+	return ok && strings.Contains(apiErr.Message, "no router with tls support")
+}
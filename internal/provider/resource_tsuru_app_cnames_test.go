@@ -0,0 +1,194 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppCNames(t *testing.T) {
+	fakeServer := echo.New()
+
+	cnames := []string{"keep.example.com", "old.example.com"}
+	calls := []string{}
+	routerPolls := 0
+	certificatePolls := 0
+	movedFromOtherApp := false
+	// the router configures the cnames of the app one poll later
+	routed := []string{}
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		routerPolls++
+		status := "ready"
+		if routerPolls == 2 {
+			status = "not ready"
+		}
+		addresses := []string{"app01.example.com"}
+		for _, cname := range routed {
+			addresses = append(addresses, "http://"+cname)
+		}
+		routed = append([]string{}, cnames...)
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"name":  c.Param("name"),
+			"cname": cnames,
+			"routers": []map[string]interface{}{
+				{"name": "ingress", "status": status, "addresses": addresses},
+				{"name": "legacy"},
+			},
+		})
+	})
+
+	fakeServer.POST("/1.0/apps/:app/cname", func(c echo.Context) error {
+		cname := tsuru.AppCName{}
+		c.Bind(&cname)
+		if !movedFromOtherApp {
+			movedFromOtherApp = true
+			return c.String(http.StatusBadRequest, "cname new.example.com already exists for app app02 using same router")
+		}
+		calls = append(calls, "add "+strings.Join(cname.Cname, ","))
+		cnames = append(cnames, cname.Cname...)
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.DELETE("/1.0/apps/:app/cname", func(c echo.Context) error {
+		cname := tsuru.AppCName{}
+		c.Bind(&cname)
+		calls = append(calls, "remove "+strings.Join(cname.Cname, ","))
+		cnames, _ = diffStringSets(cname.Cname, cnames)
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.GET("/1.24/apps/:app/certificate", func(c echo.Context) error {
+		certificatePolls++
+		certificate := ""
+		if certificatePolls > 1 {
+			certificate = "-----BEGIN CERTIFICATE-----"
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCertificates{
+			Routers: map[string]tsuru.AppCertificatesRouters{
+				"ingress": {
+					Cnames: map[string]tsuru.AppCertificatesCnames{
+						"keep.example.com": {Certificate: "-----BEGIN CERTIFICATE-----"},
+						"new.example.com":  {Issuer: "letsencrypt", Certificate: certificate},
+					},
+				},
+			},
+		})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_cnames.cnames"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Empty(t, cnames)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppCNames_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "cnames.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cnames.*", "keep.example.com"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cnames.*", "new.example.com"),
+					func(s *terraform.State) error {
+						assert.Equal(t, []string{"add new.example.com", "remove old.example.com"}, calls)
+						assert.GreaterOrEqual(t, routerPolls, 3)
+						assert.GreaterOrEqual(t, certificatePolls, 2)
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_router", "wait_for_certificates"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruAppCNamesWithoutTLSRouter(t *testing.T) {
+	fakeServer := echo.New()
+
+	cnames := []string{}
+	certificatePolls := 0
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"name":    c.Param("name"),
+			"cname":   cnames,
+			"routers": []map[string]interface{}{{"name": "legacy"}},
+		})
+	})
+
+	fakeServer.POST("/1.0/apps/:app/cname", func(c echo.Context) error {
+		cname := tsuru.AppCName{}
+		c.Bind(&cname)
+		cnames = append(cnames, cname.Cname...)
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.DELETE("/1.0/apps/:app/cname", func(c echo.Context) error {
+		cnames = []string{}
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.GET("/1.24/apps/:app/certificate", func(c echo.Context) error {
+		certificatePolls++
+		return c.String(http.StatusInternalServerError, "no router with tls support\n")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppCNames_basic(),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, 1, certificatePolls)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppCNames_basic() string {
+	return `
+	resource "tsuru_app_cnames" "cnames" {
+		app                   = "app01"
+		cnames                = ["keep.example.com", "new.example.com"]
+		wait_for_router       = true
+		wait_for_certificates = true
+	}
+`
+}
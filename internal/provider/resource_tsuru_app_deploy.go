@@ -95,6 +95,12 @@ func resourceTsuruApplicationDeployDo(ctx context.Context, d *schema.ResourceDat
 
 	app := d.Get("app").(string)
 
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
 	values := url.Values{}
 	values.Set("origin", "image")
 	values.Set("image", d.Get("image").(string))
@@ -120,6 +126,9 @@ func resourceTsuruApplicationDeployDo(ctx context.Context, d *schema.ResourceDat
 
 	wait := d.Get("wait").(bool)
 
+	// the units change until the deploy finishes
+	defer provider.apps.invalidate(app)
+
 	resp, err := http.DefaultClient.Do(req)
 
 	if err != nil {
@@ -138,6 +147,9 @@ func resourceTsuruApplicationDeployDo(ctx context.Context, d *schema.ResourceDat
 	eventID := resp.Header.Get("X-Tsuru-Eventid")
 	d.SetId(eventID)
 
+	// tsuru holds its own event lock on the app until the deploy finishes
+	unlock()
+
 	if wait {
 		scanner := bufio.NewScanner(resp.Body)
 		for scanner.Scan() {
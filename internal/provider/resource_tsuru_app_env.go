@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -84,19 +82,20 @@ func resourceTsuruApplicationEnvironmentCreate(ctx context.Context, d *schema.Re
 		envs.Norestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		if len(envs.Envs) == 0 {
-			return resource.NonRetryableError(errors.Errorf("No environment variables to create"))
-		}
-		resp, err := provider.TsuruClient.AppApi.EnvSet(ctx, app, *envs)
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	if len(envs.Envs) == 0 {
+		return diag.Errorf("No environment variables to create")
+	}
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		resp, err := provider.TsuruClient.AppApi.EnvSet(withIdempotentRequest(ctx), app, *envs)
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
+			return err
 		}
 
 		defer resp.Body.Close()
@@ -105,7 +104,7 @@ func resourceTsuruApplicationEnvironmentCreate(ctx context.Context, d *schema.Re
 		return nil
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to set envs of app %s", app)
 	}
 
 	d.SetId(app)
@@ -123,7 +122,7 @@ func resourceTsuruApplicationEnvironmentRead(ctx context.Context, d *schema.Reso
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read envs for app %s: %v", app, err)
+		return tsuruDiagnostics(err, "unable to read envs for app %s", app)
 	}
 	
 	envs = filterUnmanagedTerraformEnvs(envs, provider.FullManagementEnvs)
@@ -162,24 +161,22 @@ func resourceTsuruApplicationEnvironmentUpdate(ctx context.Context, d *schema.Re
 		envs.Norestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		if len(envs.Envs) == 0 {
-			return resource.NonRetryableError(errors.Errorf("No environment variables to update"))
-		}
-		_, err := provider.TsuruClient.AppApi.EnvSet(ctx, app, *envs)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	if len(envs.Envs) == 0 {
+		return diag.Errorf("No environment variables to update")
+	}
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		_, err := provider.TsuruClient.AppApi.EnvSet(withIdempotentRequest(ctx), app, *envs)
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to update envs of app %s", app)
 	}
 
 	return resourceTsuruApplicationEnvironmentRead(ctx, d, meta)
@@ -196,25 +193,22 @@ func resourceTsuruApplicationEnvironmentDelete(ctx context.Context, d *schema.Re
 		noRestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		_, err := provider.TsuruClient.AppApi.EnvSet(ctx, app, tsuru_client.EnvSetData{
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		_, err := provider.TsuruClient.AppApi.EnvSet(withIdempotentRequest(ctx), app, tsuru_client.EnvSetData{
 			Envs:      []tsuru.Env{},
 			ManagedBy: "terraform",
 			Norestart: noRestart,
 		})
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to remove envs of app %s", app)
 	}
 
 	return nil
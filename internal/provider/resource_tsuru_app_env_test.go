@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -80,6 +83,157 @@ func TestAccResourceTsuruAppEnv(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruAppEnv_transientErrors(t *testing.T) {
+	fakeServer := echo.New()
+
+	var mu sync.Mutex
+	envs := []tsuru.EnvVar{}
+	reads := 0
+	writes := 0
+
+	fakeServer.GET("/1.0/apps/:app/env", func(c echo.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		reads++
+		if reads == 1 {
+			return c.String(http.StatusBadGateway, "bad gateway")
+		}
+		return c.JSON(http.StatusOK, envs)
+	})
+
+	fakeServer.POST("/1.0/apps/:app/env", func(c echo.Context) error {
+		data := tsuru.EnvSetData{}
+		c.Bind(&data)
+
+		mu.Lock()
+		defer mu.Unlock()
+		writes++
+		switch writes {
+		case 1:
+			c.Response().Header().Set("Retry-After", "0")
+			return c.String(http.StatusServiceUnavailable, "unavailable")
+		case 2:
+			return c.String(http.StatusConflict, "event locked: app.update.env.set")
+		}
+
+		envs = []tsuru.EnvVar{}
+		for _, env := range data.Envs {
+			envs = append(envs, tsuru.EnvVar{Name: env.Name, Value: env.Value, Public: !env.Private, ManagedBy: "terraform"})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_env.env"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	provider "tsuru" {
+		max_retries    = 2
+		retry_max_wait = 1
+	}
+` + testAccResourceTsuruAppEnv_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_variables.env1", "10"),
+					resource.TestCheckResourceAttr(resourceName, "private_environment_variables.env2", "12"),
+					func(s *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+						assert.Equal(t, 3, writes)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruAppEnv_connectionClosed(t *testing.T) {
+	fakeServer := echo.New()
+
+	var mu sync.Mutex
+	envs := []tsuru.EnvVar{}
+	writes := 0
+
+	fakeServer.GET("/1.0/apps/:app/env", func(c echo.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.JSON(http.StatusOK, envs)
+	})
+
+	fakeServer.POST("/1.0/apps/:app/env", func(c echo.Context) error {
+		data := tsuru.EnvSetData{}
+		c.Bind(&data)
+
+		mu.Lock()
+		defer mu.Unlock()
+		writes++
+		if writes == 2 {
+			return closeConnection(c)
+		}
+
+		envs = []tsuru.EnvVar{}
+		for _, env := range data.Envs {
+			envs = append(envs, tsuru.EnvVar{Name: env.Name, Value: env.Value, Public: !env.Private, ManagedBy: "terraform"})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	provider := `
+	provider "tsuru" {
+		max_retries = 0
+	}
+`
+
+	resourceName := "tsuru_app_env.env"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccResourceTsuruAppEnv_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_variables.env1", "10"),
+				),
+			},
+			{
+				Config: provider + `
+	resource "tsuru_app_env" "env" {
+		app = "app01"
+		restart_on_update = false
+		environment_variables = {
+			env1 = "20"
+		}
+
+		private_environment_variables = {
+			env2 = "12"
+		}
+	}
+`,
+				ExpectError: regexp.MustCompile(`(?s)unable to update envs of app app01.*unable to reach the tsuru API`),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruAppEnv_basic() string {
 	return `
 	resource "tsuru_app_env" "env" {
@@ -10,10 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
-	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
 func resourceTsuruApplicationGrant() *schema.Resource {
@@ -53,25 +50,22 @@ func resourceTsuruApplicationGrantCreate(ctx context.Context, d *schema.Resource
 	app := d.Get("app").(string)
 	team := d.Get("team").(string)
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		response, err := provider.TsuruClient.AppApi.AppTeamGrant(ctx, app, team)
 		// ignore teams already granted for this app
-		if response.StatusCode == http.StatusConflict {
+		if response != nil && response.StatusCode == http.StatusConflict {
 			return nil
 		}
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(errors.Errorf("unable to add team grant: %v", err))
-			}
-		}
-		return nil
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to grant team %s access to app %s", team, app)
 	}
 
 	d.SetId(createID([]string{app, team}))
@@ -89,13 +83,13 @@ func resourceTsuruApplicationGrantRead(ctx context.Context, d *schema.ResourceDa
 	appName := parts[0]
 	team := parts[1]
 
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, appName)
+	app, err := provider.getApp(ctx, appName)
 	if err != nil {
 		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to get app %s: %v", appName, err)
+		return tsuruDiagnostics(err, "unable to get app %s", appName)
 	}
 
 	for _, t := range app.Teams {
@@ -116,22 +110,18 @@ func resourceTsuruApplicationGrantDelete(ctx context.Context, d *schema.Resource
 	app := d.Get("app").(string)
 	team := d.Get("team").(string)
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
 		_, err := provider.TsuruClient.AppApi.AppTeamRevoke(ctx, app, team)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(errors.Errorf("unable to revoke team grant: %v", err))
-		}
-		return nil
+		return err
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to revoke team %s access to app %s", team, app)
 	}
 
 	return nil
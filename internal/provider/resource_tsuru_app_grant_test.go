@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -150,6 +151,40 @@ func TestAccResourceTsuruAppGrant(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruAppGrant_connectionClosed(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &tsuru.App{Name: c.Param("name"), TeamOwner: "myteam"})
+	})
+
+	fakeServer.PUT("/1.0/apps/:app/teams/:team", func(c echo.Context) error {
+		return closeConnection(c)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	provider "tsuru" {
+		max_retries = 0
+	}
+` + testAccResourceTsuruAppGrant_basic(),
+				ExpectError: regexp.MustCompile(`(?s)unable to grant team mysupport-team access to app app01.*unable to reach the tsuru API`),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruAppGrant_basic() string {
 	return `
 	resource "tsuru_app_grant" "team" {
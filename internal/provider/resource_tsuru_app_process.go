@@ -0,0 +1,379 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func resourceTsuruApplicationProcess() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Application Process, plan, metadata and scale of a single process of an app, do not manage the same process with the process block of tsuru_app",
+		CreateContext: resourceTsuruApplicationProcessSet,
+		ReadContext:   resourceTsuruApplicationProcessRead,
+		UpdateContext: resourceTsuruApplicationProcessSet,
+		DeleteContext: resourceTsuruApplicationProcessDelete,
+		CustomizeDiff: resourceTsuruApplicationProcessCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Process name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Description: "Plan of the process, the app plan is used when empty",
+				Optional:    true,
+			},
+			"metadata": metadataSchema(),
+			"units": {
+				Type:          schema.TypeInt,
+				Description:   "Units of the process, the current units are kept when unset",
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"autoscale"},
+			},
+			"autoscale": {
+				Type:          schema.TypeList,
+				Description:   "Autoscale of the process, do not use it when the autoscale of the process is managed by tsuru_app_autoscale",
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"units"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_units": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"max_units": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"cpu_average": {
+							Type:        schema.TypeString,
+							Description: "Target CPU average, in millis (e.g. 800m)",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"restart_on_update": {
+				Type:        schema.TypeBool,
+				Description: "Restart app after changing the plan or metadata of the process",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationProcessCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("units") || d.Get("units").(int) == 0 {
+		return nil
+	}
+
+	for _, key := range []string{"app", "name", "units"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	provider := meta.(*tsuruProvider)
+	return checkAppUnitQuota(ctx, provider, d.Get("app").(string), d.Get("name").(string), d.Get("units").(int))
+}
+
+func resourceTsuruApplicationProcessSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Get("app").(string)
+	name := d.Get("name").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	current := tsuru_client.AppProcess{Name: name}
+	for _, process := range app.Processes {
+		if process.Name == name {
+			current = process
+		}
+	}
+
+	desired := tsuru_client.AppProcess{Name: name, Plan: d.Get("plan").(string)}
+	if desired.Plan == "" {
+		desired.Plan = "$default"
+	}
+	if metadata := metadataFromResourceData(d.Get("metadata")); metadata != nil {
+		desired.Metadata = *metadata
+	}
+
+	d.SetId(createID([]string{appName, name}))
+
+	if !sameAppProcess(current, desired) {
+		if err = updateAppProcess(ctx, provider, appName, current, desired, !d.Get("restart_on_update").(bool), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if units, ok := configuredUnits(d); ok {
+		if err = scaleAppProcess(ctx, provider, appName, name, units, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("autoscale") {
+		if err = setAppProcessAutoscale(ctx, provider, appName, name, d.Get("autoscale").([]interface{}), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTsuruApplicationProcessRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationProcessRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	parts, err := IDtoParts(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	appName := parts[0]
+	name := parts[1]
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	plan := ""
+	metadata := []interface{}{}
+	found := false
+	for _, process := range app.Processes {
+		if process.Name != name {
+			continue
+		}
+		found = true
+		if process.Plan != "$default" {
+			plan = process.Plan
+		}
+		metadata = flattenMetadata(process.Metadata)
+	}
+
+	// tsuru only lists processes with a plan or metadata of their own, a
+	// process configured with them and missing was reset outside Terraform
+	if !found && (d.Get("plan").(string) != "" || len(d.Get("metadata").([]interface{})) > 0) {
+		log.Printf("[WARN] process %s of app %s not found, removing it from the state", name, appName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("app", appName)
+	d.Set("name", name)
+	d.Set("plan", plan)
+	d.Set("metadata", metadata)
+
+	units := 0
+	for _, unit := range app.Units {
+		if unit.Processname == name {
+			units++
+		}
+	}
+	d.Set("units", units)
+
+	autoscales, _, err := provider.TsuruClient.AppApi.AutoScaleInfo(ctx, appName)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read autoscale of app %s", appName)
+	}
+
+	autoscale := []interface{}{}
+	for _, a := range autoscales {
+		if a.Process != name {
+			continue
+		}
+		autoscale = append(autoscale, map[string]interface{}{
+			"min_units":   int(a.MinUnits),
+			"max_units":   int(a.MaxUnits),
+			"cpu_average": a.AverageCPU,
+		})
+	}
+	d.Set("autoscale", autoscale)
+
+	return nil
+}
+
+func resourceTsuruApplicationProcessDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Get("app").(string)
+	name := d.Get("name").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	if len(d.Get("autoscale").([]interface{})) > 0 {
+		if err = setAppProcessAutoscale(ctx, provider, appName, name, nil, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, process := range app.Processes {
+		if process.Name != name {
+			continue
+		}
+		removed := tsuru_client.AppProcess{Name: name, Plan: "$default"}
+		if sameAppProcess(process, removed) {
+			break
+		}
+		if err = updateAppProcess(ctx, provider, appName, process, removed, !d.Get("restart_on_update").(bool), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// updateAppProcess changes only the given process, metadata of current
+// missing on desired is deleted.
+func updateAppProcess(ctx context.Context, provider *tsuruProvider, app string, current, desired tsuru_client.AppProcess, noRestart bool, timeout time.Duration) error {
+	update := tsuru_client.UpdateApp{
+		Processes: markRemovedProcessAsDefaultPlan([]tsuru_client.AppProcess{current}, []tsuru_client.AppProcess{desired}),
+		NoRestart: noRestart,
+	}
+
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		resp, err := provider.TsuruClient.AppApi.AppUpdate(ctx, app, update)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		logTsuruStream(resp.Body)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to update process %s of app %s", desired.Name, app)
+	}
+	return nil
+}
+
+func scaleAppProcess(ctx context.Context, provider *tsuruProvider, app, process string, units int, timeout time.Duration) error {
+	current, err := countUnits(ctx, provider, app, process, nil)
+	if err != nil {
+		return err
+	}
+
+	delta := units - current
+	if delta == 0 {
+		return nil
+	}
+
+	err = tsuruRetry(ctx, provider, timeout, func() error {
+		var err error
+		if delta > 0 {
+			_, err = provider.TsuruClient.AppApi.UnitsAdd(ctx, app, tsuru_client.UnitsDelta{Units: strconv.Itoa(delta), Process: process})
+		} else {
+			_, err = provider.TsuruClient.AppApi.UnitsRemove(ctx, app, tsuru_client.UnitsDelta{Units: strconv.Itoa(-delta), Process: process})
+		}
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to scale process %s of app %s to %d units", process, app, units)
+	}
+	return nil
+}
+
+// setAppProcessAutoscale sets the autoscale of the process, an empty
+// autoscale removes it.
+func setAppProcessAutoscale(ctx context.Context, provider *tsuruProvider, app, process string, autoscale []interface{}, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		var err error
+		if len(autoscale) == 0 || autoscale[0] == nil {
+			_, err = provider.TsuruClient.AppApi.AutoScaleRemove(ctx, app, process)
+		} else {
+			m := autoscale[0].(map[string]interface{})
+			_, err = provider.TsuruClient.AppApi.AutoScaleAdd(ctx, app, tsuru_client.AutoScaleSpec{
+				Process:    process,
+				MinUnits:   int32(m["min_units"].(int)),
+				MaxUnits:   int32(m["max_units"].(int)),
+				AverageCPU: m["cpu_average"].(string),
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to set autoscale of process %s of app %s", process, app)
+	}
+	return nil
+}
+
+func configuredUnits(d *schema.ResourceData) (int, bool) {
+	units := d.GetRawConfig().GetAttr("units")
+	if units.IsNull() || !units.IsKnown() {
+		return 0, false
+	}
+	return d.Get("units").(int), true
+}
+
+func sameAppProcess(a, b tsuru_client.AppProcess) bool {
+	planA, planB := a.Plan, b.Plan
+	if planA == "" {
+		planA = "$default"
+	}
+	if planB == "" {
+		planB = "$default"
+	}
+	if planA != planB {
+		return false
+	}
+
+	return reflect.DeepEqual(flattenMetadata(a.Metadata), flattenMetadata(b.Metadata))
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppProcess(t *testing.T) {
+	fakeServer := echo.New()
+
+	app := &tsuru.App{
+		Name: "app01",
+		Processes: []tsuru.AppProcess{
+			{Name: "web", Plan: "c1m1"},
+			{
+				Name: "worker",
+				Plan: "$default",
+				Metadata: tsuru.Metadata{
+					Labels: []tsuru.MetadataItem{{Name: "old-label", Value: "old"}},
+				},
+			},
+		},
+		Units: []tsuru.Unit{{Processname: "web"}, {Processname: "worker"}},
+	}
+	autoscales := []tsuru.AutoScaleSpec{}
+	updates := 0
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: -1})
+	})
+
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		update := tsuru.UpdateApp{}
+		c.Bind(&update)
+		updates++
+		assert.Len(t, update.Processes, 1)
+		assert.Equal(t, "worker", update.Processes[0].Name)
+
+		process := update.Processes[0]
+		if !slices.ContainsFunc(app.Processes, func(p tsuru.AppProcess) bool { return p.Name == process.Name }) {
+			app.Processes = append(app.Processes, tsuru.AppProcess{Name: process.Name})
+		}
+		for i := range app.Processes {
+			if app.Processes[i].Name != process.Name {
+				continue
+			}
+			app.Processes[i].Plan = process.Plan
+			app.Processes[i].Metadata = tsuru.Metadata{}
+			for _, label := range process.Metadata.Labels {
+				if !label.Delete {
+					app.Processes[i].Metadata.Labels = append(app.Processes[i].Metadata.Labels, label)
+				}
+			}
+			for _, annotation := range process.Metadata.Annotations {
+				if !annotation.Delete {
+					app.Processes[i].Metadata.Annotations = append(app.Processes[i].Metadata.Annotations, annotation)
+				}
+			}
+		}
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.PUT("/1.0/apps/:app/units", func(c echo.Context) error {
+		delta := tsuru.UnitsDelta{}
+		c.Bind(&delta)
+		assert.Equal(t, "worker", delta.Process)
+		units, _ := strconv.Atoi(delta.Units)
+		for i := 0; i < units; i++ {
+			app.Units = append(app.Units, tsuru.Unit{Processname: delta.Process})
+		}
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.POST("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
+		autoscale := tsuru.AutoScaleSpec{}
+		c.Bind(&autoscale)
+		autoscales = []tsuru.AutoScaleSpec{autoscale}
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.GET("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, autoscales)
+	})
+
+	fakeServer.DELETE("/1.9/apps/:app/units/autoscale", func(c echo.Context) error {
+		assert.Equal(t, "worker", c.QueryParam("process"))
+		autoscales = []tsuru.AutoScaleSpec{}
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_process.worker"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Equal(t, tsuru.AppProcess{Name: "worker", Plan: "$default"}, app.Processes[1])
+			assert.Empty(t, autoscales)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppProcess_units("c2m2", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "name", "worker"),
+					resource.TestCheckResourceAttr(resourceName, "plan", "c2m2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.team", "payments"),
+					resource.TestCheckNoResourceAttr(resourceName, "metadata.0.labels.old-label"),
+					resource.TestCheckResourceAttr(resourceName, "units", "3"),
+					func(s *terraform.State) error {
+						assert.Equal(t, 1, updates)
+						// other processes are left untouched
+						assert.Equal(t, tsuru.AppProcess{Name: "web", Plan: "c1m1"}, app.Processes[0])
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTsuruAppProcess_autoscale("c2m2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "autoscale.0.min_units", "2"),
+					resource.TestCheckResourceAttr(resourceName, "autoscale.0.max_units", "5"),
+					resource.TestCheckResourceAttr(resourceName, "autoscale.0.cpu_average", "800m"),
+					func(s *terraform.State) error {
+						// plan and metadata unchanged, no app update
+						assert.Equal(t, 1, updates)
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "app01::worker",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"restart_on_update"},
+			},
+			{
+				// the process is reset outside Terraform
+				PreConfig: func() {
+					app.Processes = app.Processes[:1]
+				},
+				Config: testAccResourceTsuruAppProcess_autoscale("c2m2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "plan", "c2m2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.team", "payments"),
+					func(s *terraform.State) error {
+						assert.Equal(t, 2, updates)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppProcess_units(plan string, units int) string {
+	return fmt.Sprintf(`
+resource "tsuru_app_process" "worker" {
+  app   = "app01"
+  name  = "worker"
+  plan  = %q
+  units = %d
+
+  metadata {
+    labels = {
+      "team" = "payments"
+    }
+  }
+}
+`, plan, units)
+}
+
+func testAccResourceTsuruAppProcess_autoscale(plan string) string {
+	return fmt.Sprintf(`
+resource "tsuru_app_process" "worker" {
+  app  = "app01"
+  name = "worker"
+  plan = %q
+
+  metadata {
+    labels = {
+      "team" = "payments"
+    }
+  }
+
+  autoscale {
+    min_units   = 2
+    max_units   = 5
+    cpu_average = "800m"
+  }
+}
+`, plan)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceTsuruApplicationQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Application Quota",
+		CreateContext: resourceTsuruApplicationQuotaCreate,
+		ReadContext:   resourceTsuruApplicationQuotaRead,
+		UpdateContext: resourceTsuruApplicationQuotaUpdate,
+		DeleteContext: resourceTsuruApplicationQuotaDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of units of the application, -1 means unlimited. The quota goes back to unlimited when this resource is destroyed",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(unlimitedQuota),
+			},
+			"in_use": {
+				Type:        schema.TypeInt,
+				Description: "Number of units in use by the application",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+
+	if err := changeAppQuota(ctx, provider, app, d.Get("limit").(int), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of app %s", app)
+	}
+
+	d.SetId(app)
+
+	return resourceTsuruApplicationQuotaRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Id()
+
+	quota, err := getAppQuota(ctx, provider, app)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read quota of app %s", app)
+	}
+
+	d.Set("app", app)
+	flattenQuota(d, quota)
+
+	return nil
+}
+
+func resourceTsuruApplicationQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Id()
+
+	if err := changeAppQuota(ctx, provider, app, d.Get("limit").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of app %s", app)
+	}
+
+	return resourceTsuruApplicationQuotaRead(ctx, d, meta)
+}
+
+// resourceTsuruApplicationQuotaDelete sets the quota back to unlimited.
+func resourceTsuruApplicationQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Id()
+
+	err := changeAppQuota(ctx, provider, app, unlimitedQuota, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !isNotFoundError(err) {
+		return tsuruDiagnostics(err, "unable to reset quota of app %s", app)
+	}
+
+	return nil
+}
+
+// checkAppUnitQuota reports when running units units of process would exceed
+// the unit quota of app. The check is skipped when the app does not exist yet,
+// e.g. it is created on the same plan, as tsuru still enforces the quota.
+func checkAppUnitQuota(ctx context.Context, provider *tsuruProvider, app, process string, units int) error {
+	quota, err := getAppQuota(ctx, provider, app)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return errors.Errorf("unable to check the unit quota of app %s: %s", app, describeTsuruError(err))
+	}
+
+	if quota.Limit < 0 {
+		return nil
+	}
+
+	current, err := countUnits(ctx, provider, app, process, nil)
+	if err != nil {
+		return err
+	}
+
+	otherUnits := int(quota.Inuse) - current
+	if otherUnits < 0 {
+		otherUnits = 0
+	}
+
+	if otherUnits+units > int(quota.Limit) {
+		return errors.Errorf("%d units of process %s exceed the unit quota of app %s: limit is %d, %d units in use by other processes", units, process, app, quota.Limit, otherUnits)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppQuota(t *testing.T) {
+	fakeServer := echo.New()
+
+	quota := tsuru.Quota{Limit: unlimitedQuota, Inuse: 4}
+	limits := []string{}
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		assert.Equal(t, "app01", c.Param("app"))
+		return c.JSON(http.StatusOK, quota)
+	})
+
+	fakeServer.PUT("/1.0/apps/:app/quota", func(c echo.Context) error {
+		assert.Equal(t, "app01", c.Param("app"))
+		limit, err := strconv.Atoi(c.FormValue("limit"))
+		assert.NoError(t, err)
+		limits = append(limits, c.FormValue("limit"))
+		quota.Limit = int64(limit)
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_quota.quota"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Equal(t, []string{"10", "20", "-1"}, limits)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppQuota(10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "limit", "10"),
+					resource.TestCheckResourceAttr(resourceName, "in_use", "4"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruAppQuota(20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "limit", "20"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppQuota(limit int) string {
+	return fmt.Sprintf(`
+	resource "tsuru_app_quota" "quota" {
+		app   = "app01"
+		limit = %d
+	}
+`, limit)
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func resourceTsuruApplicationRestart() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Restart an application or one of its processes, it restarts again when any of the triggers change",
+		CreateContext: resourceTsuruApplicationRestartDo,
+		ReadContext:   resourceTsuruApplicationRestartRead,
+		DeleteContext: resourceTsuruApplicationRestartDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"process": {
+				Type:        schema.TypeString,
+				Description: "Process to restart, all processes are restarted when empty",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that restart the application when changed, e.g. the version of a rotated secret",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationRestartDo(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+	process := d.Get("process").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	if err = appLifecycle(ctx, provider, app, "restart", process, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := app
+	if process != "" {
+		id = createID([]string{app, process})
+	}
+	d.SetId(id)
+
+	return resourceTsuruApplicationRestartRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationRestartRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	app := d.Get("app").(string)
+
+	_, err := provider.getApp(ctx, app)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", app)
+	}
+
+	return nil
+}
+
+func resourceTsuruApplicationRestartDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] delete a restart is a no-op by terraform")
+	return nil
+}
+
+// appLifecycle calls the start, stop or restart action of the app, logs the
+// output streamed by tsuru and waits for the event of the action.
+func appLifecycle(ctx context.Context, provider *tsuruProvider, app, action, process string, timeout time.Duration) error {
+	target := "app " + app
+	if process != "" {
+		target = "process " + process + " of app " + app
+	}
+
+	body, err := json.Marshal(tsuru_client.AppStartStop{Process: process})
+	if err != nil {
+		return err
+	}
+
+	// the units change until the action finishes
+	defer provider.apps.invalidate(app)
+
+	var eventID string
+	err = tsuruRetry(ctx, provider, timeout, func() error {
+		resp, err := tsuruRequest(ctx, provider, http.MethodPost, "/1.0/apps/"+url.PathEscape(app)+"/"+action, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		eventID = resp.Header.Get("X-Tsuru-Eventid")
+		return readTsuruStream(resp.Body)
+	})
+	if err != nil {
+		return errors.Errorf("unable to %s %s: %v", action, target, err)
+	}
+
+	// tsuru only sends the event ID on deploys
+	if eventID == "" {
+		eventID, err = lastAppEventID(ctx, provider, app, "app.update."+action)
+		if err != nil {
+			return errors.Errorf("unable to find the %s event of %s: %v", action, target, err)
+		}
+	}
+	if eventID == "" {
+		return nil
+	}
+
+	if err = waitForEventComplete(ctx, provider, eventID); err != nil {
+		return errors.Errorf("unable to %s %s: %v", action, target, err)
+	}
+
+	return nil
+}
+
+// lastAppEventID returns the ID of the last event of kind on the app.
+func lastAppEventID(ctx context.Context, provider *tsuruProvider, app, kind string) (string, error) {
+	events, err := listEvents(ctx, provider, eventListQuery([]string{kind}, "app", app, 1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(events) == 0 {
+		return "", nil
+	}
+
+	return events[0].UniqueID, nil
+}
+
+// unitsState returns started when any unit of the processes accepted by
+// filter is started or ready, stopped when none is, and empty when there are
+// no units. tsuru removes the units of stopped processes on kubernetes, but
+// processes scaled to zero have no units too, so empty is neither state.
+func unitsState(units []tsuru_client.Unit, filter func(process string) bool) string {
+	state := "empty"
+	for _, unit := range units {
+		if !filter(unit.Processname) {
+			continue
+		}
+		if unit.Status == "started" || unit.Status == "ready" {
+			return "started"
+		}
+		state = "stopped"
+	}
+
+	return state
+}
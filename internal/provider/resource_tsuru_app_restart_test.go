@@ -0,0 +1,148 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppRestart(t *testing.T) {
+	fakeServer := echo.New()
+
+	restarts := []string{}
+	fakeServer.POST("/1.0/apps/:app/restart", func(c echo.Context) error {
+		input := tsuru.AppStartStop{}
+		c.Bind(&input)
+		assert.Equal(t, "app01", c.Param("app"))
+		restarts = append(restarts, input.Process)
+		c.Response().Header().Set("Content-Type", "application/x-json-stream")
+		return c.String(http.StatusOK, `{"Message":"restarting units\n"}`+"\n")
+	})
+
+	fakeServer.GET("/1.1/events", func(c echo.Context) error {
+		assert.Equal(t, "app.update.restart", c.QueryParam("kindname"))
+		return c.JSON(http.StatusOK, []tsuru.Event{{UniqueID: "evt-1"}})
+	})
+	fakeServer.GET("/1.1/events/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Event{UniqueID: c.Param("id")})
+	})
+
+	fakeServer.GET("/1.0/apps/:app", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.App{Name: c.Param("app")})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_restart.restart"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppRestart_basic("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", "app01::web"),
+					func(s *terraform.State) error {
+						assert.Equal(t, []string{"web"}, restarts)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTsuruAppRestart_basic("1"),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, []string{"web"}, restarts)
+					return nil
+				},
+			},
+			{
+				Config: testAccResourceTsuruAppRestart_basic("2"),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, []string{"web", "web"}, restarts)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruAppRestart_failed(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.POST("/1.0/apps/:app/restart", func(c echo.Context) error {
+		c.Response().Header().Set("Content-Type", "application/x-json-stream")
+		return c.String(http.StatusOK, `{"Message":"restarting units\n"}`+"\n"+`{"Error":"units not ready"}`+"\n")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruAppRestart_basic("1"),
+				ExpectError: regexp.MustCompile(`unable to restart process web of app app01: units not ready`),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppRestart_basic(version string) string {
+	return fmt.Sprintf(`
+resource "tsuru_app_restart" "restart" {
+	app     = "app01"
+	process = "web"
+
+	triggers = {
+		secret_version = "%s"
+	}
+}
+`, version)
+}
+
+func TestUnitsState(t *testing.T) {
+	all := func(string) bool { return true }
+	web := func(process string) bool { return process == "web" }
+
+	tests := []struct {
+		units    []tsuru.Unit
+		filter   func(string) bool
+		expected string
+	}{
+		{nil, all, "empty"},
+		{[]tsuru.Unit{{Processname: "worker", Status: "started"}}, web, "empty"},
+		{[]tsuru.Unit{{Processname: "web", Status: "stopped"}}, all, "stopped"},
+		{[]tsuru.Unit{{Processname: "web", Status: "error"}, {Processname: "web", Status: "asleep"}}, all, "stopped"},
+		{[]tsuru.Unit{{Processname: "web", Status: "stopped"}, {Processname: "web", Status: "ready"}}, all, "started"},
+		{[]tsuru.Unit{{Processname: "web", Status: "started"}}, web, "started"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, unitsState(tt.units, tt.filter), "%+v", tt.units)
+	}
+}
@@ -6,7 +6,11 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -23,6 +27,7 @@ func resourceTsuruApplicationRouter() *schema.Resource {
 		ReadContext:   resourceTsuruApplicationRouterRead,
 		UpdateContext: resourceTsuruApplicationRouterUpdate,
 		DeleteContext: resourceTsuruApplicationRouterDelete,
+		CustomizeDiff: resourceTsuruApplicationRouterCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -44,23 +49,54 @@ func resourceTsuruApplicationRouter() *schema.Resource {
 			},
 			"options": {
 				Type:        schema.TypeMap,
-				Description: "Application description",
+				Description: "Router options, keys with a / are passed through as annotations",
 				Optional:    true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"strict_options": {
+				Type:        schema.TypeBool,
+				Description: "Fail the plan on options the provider can not validate: options unknown to the router type, or any option of a router type the provider does not know the options of. When false they are sent to the router with a warning",
+				Optional:    true,
+				Default:     true,
+			},
 		},
 	}
 }
 
+func resourceTsuruApplicationRouterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("strict_options").(bool) || !d.NewValueKnown("name") || !d.NewValueKnown("options") {
+		return nil
+	}
+	options := d.Get("options").(map[string]interface{})
+	if len(options) == 0 {
+		return nil
+	}
+
+	provider := meta.(*tsuruProvider)
+	name := d.Get("name").(string)
+
+	router, err := findRouter(ctx, provider, name)
+	if err != nil {
+		return err
+	}
+
+	if message := invalidRouterOptionsMessage(router, options); message != "" {
+		return errors.Errorf("%s, set strict_options = false to send them anyway", message)
+	}
+
+	return nil
+}
+
 func resourceTsuruApplicationRouterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 	appName := d.Get("app").(string)
 	name := d.Get("name").(string)
 
-	if err := validRouter(ctx, provider, name); err != nil {
-		return diag.Errorf("unable to create router: %v", err)
+	planRouter, err := findRouter(ctx, provider, name)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to create router")
 	}
 
 	options := map[string]interface{}{}
@@ -73,26 +109,30 @@ func resourceTsuruApplicationRouterCreate(ctx context.Context, d *schema.Resourc
 		Opts: options,
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		_, err := provider.TsuruClient.AppApi.AppRouterAdd(ctx, appName, router)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(errors.Errorf("unable to create router: %v", err))
-			}
-		}
-		d.SetId(createID([]string{appName, name}))
-		return nil
+		return err
 	})
-
 	if err != nil {
+		return tsuruDiagnostics(err, "unable to add router %s to app %s", name, appName)
+	}
+
+	d.SetId(createID([]string{appName, name}))
+
+	// other resources of the app don't wait for the router
+	unlock()
+
+	if err = waitAppRouterReadinessGates(ctx, provider, appName, planRouter, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return resourceTsuruApplicationRouterRead(ctx, d, meta)
+	return append(routerOptionsWarnings(planRouter, options), resourceTsuruApplicationRouterRead(ctx, d, meta)...)
 }
 
 func resourceTsuruApplicationRouterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -118,7 +158,7 @@ func resourceTsuruApplicationRouterRead(ctx context.Context, d *schema.ResourceD
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to get app %s: %v", appName, err)
+		return tsuruDiagnostics(err, "unable to get app %s", appName)
 	}
 
 	d.Set("app", appName)
@@ -150,25 +190,33 @@ func resourceTsuruApplicationRouterUpdate(ctx context.Context, d *schema.Resourc
 		Opts: options,
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+	planRouter, err := findRouter(ctx, provider, name)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to update router")
+	}
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
 		_, err := provider.TsuruClient.AppApi.AppRouterUpdate(ctx, appName, name, router)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+		return err
 	})
-
 	if err != nil {
+		return tsuruDiagnostics(err, "unable to update router %s of app %s", name, appName)
+	}
+
+	// other resources of the app don't wait for the router
+	unlock()
+
+	if err = waitAppRouterReadinessGates(ctx, provider, appName, planRouter, d.Timeout(schema.TimeoutUpdate)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return resourceTsuruApplicationRouterRead(ctx, d, meta)
+	return append(routerOptionsWarnings(planRouter, options), resourceTsuruApplicationRouterRead(ctx, d, meta)...)
 }
 
 func resourceTsuruApplicationRouterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -176,9 +224,15 @@ func resourceTsuruApplicationRouterDelete(ctx context.Context, d *schema.Resourc
 	appName := d.Get("app").(string)
 	name := d.Get("name").(string)
 
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
 	resp, err := provider.TsuruClient.AppApi.AppRouterDelete(ctx, appName, name)
 	if err != nil {
-		return diag.Errorf("unable to delete router: %v", err)
+		return tsuruDiagnostics(err, "unable to delete router")
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -188,16 +242,112 @@ func resourceTsuruApplicationRouterDelete(ctx context.Context, d *schema.Resourc
 	return nil
 }
 
-func validRouter(ctx context.Context, provider *tsuruProvider, router string) error {
+func findRouter(ctx context.Context, provider *tsuruProvider, router string) (*tsuru_client.PlanRouter, error) {
 	routers, _, err := provider.TsuruClient.RouterApi.RouterList(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, r := range routers {
 		if r.Name == router {
-			return nil
+			return &r, nil
+		}
+	}
+	return nil, errors.Errorf("invalid router: %s", router)
+}
+
+// routerTypeOptions lists the options understood by each router type. tsuru
+// only registers routers of type api, served by kubernetes-router, whose
+// options are the fields of its router.Opts; other keys are only accepted as
+// annotations.
+var routerTypeOptions = map[string][]string{
+	"api": {
+		"domain",
+		"exposed-port",
+		"expose-all-services",
+		"route",
+		"tls-acme",
+		"tls-acme-cname",
+	},
+}
+
+// invalidRouterOptionsMessage describes the options of the router the
+// provider can not validate, the ones missing from the options of its type
+// or all of them when the type is missing from routerTypeOptions. It is
+// empty when every option is known.
+func invalidRouterOptionsMessage(router *tsuru_client.PlanRouter, options map[string]interface{}) string {
+	keys := []string{}
+	for key := range options {
+		// annotations, e.g. nginx.ingress.kubernetes.io/proxy-body-size
+		if strings.Contains(key, "/") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	known, ok := routerTypeOptions[router.Type]
+	if !ok {
+		if len(keys) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("unable to validate options [%s] of router %s, the options of router type %q are not known by the provider", strings.Join(keys, ", "), router.Name, router.Type)
+	}
+
+	unknown := []string{}
+	for _, key := range keys {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
 		}
 	}
-	return errors.Errorf("invalid router: %s", router)
+	if len(unknown) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("unknown options [%s] for router %s of type %s, known options are [%s]", strings.Join(unknown, ", "), router.Name, router.Type, strings.Join(known, ", "))
+}
+
+// routerOptionsWarnings warns about the options sent to the router the
+// provider can not validate when strict_options is false, the router may
+// still understand them.
+func routerOptionsWarnings(router *tsuru_client.PlanRouter, options map[string]interface{}) diag.Diagnostics {
+	message := invalidRouterOptionsMessage(router, options)
+	if message == "" {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  message,
+		Detail:   "The options were sent to the router anyway as strict_options is false.",
+	}}
+}
+
+// waitAppRouterReadinessGates waits until the router reports the app as
+// ready, routers with readiness gates only route to units once their
+// conditions are satisfied.
+func waitAppRouterReadinessGates(ctx context.Context, provider *tsuruProvider, app string, router *tsuru_client.PlanRouter, timeout time.Duration) error {
+	if len(router.ReadinessGates) == 0 {
+		return nil
+	}
+
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		routers, _, err := provider.TsuruClient.AppApi.AppRouterList(ctx, app)
+		if err != nil {
+			return resource.NonRetryableError(errors.Errorf("unable to list routers of app %s: %v", app, err))
+		}
+
+		for _, r := range routers {
+			if r.Name != router.Name {
+				continue
+			}
+			// routers without status support report an empty status
+			if r.Status != "" && r.Status != "ready" {
+				return resource.RetryableError(errors.Errorf("router %s of app %s is %s, waiting readiness gates [%s]: %s", r.Name, app, r.Status, strings.Join(router.ReadinessGates, ", "), r.StatusDetail))
+			}
+			return nil
+		}
+
+		return resource.RetryableError(errors.Errorf("router %s not found on app %s", router.Name, app))
+	})
 }
@@ -8,11 +8,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -86,6 +90,7 @@ func testAccResourceTsuruAppRouter_basic() string {
 	resource "tsuru_app_router" "router" {
 		app = "app01"
 		name = "some-router"
+		strict_options = false
 		options = {
 			"key1" = "value1"
 			"key2" = "value2"
@@ -93,3 +98,129 @@ func testAccResourceTsuruAppRouter_basic() string {
 	}
 `
 }
+
+func TestAccResourceTsuruAppRouterReadinessGates(t *testing.T) {
+	fakeServer := echo.New()
+
+	added := false
+	polls := 0
+
+	fakeServer.GET("/1.3/routers", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.PlanRouter{{
+			Name:           "ingress-router",
+			Type:           "api",
+			ReadinessGates: []string{"ingress.tsuru.io/ready"},
+		}})
+	})
+
+	fakeServer.GET("/1.5/apps/:app/routers", func(c echo.Context) error {
+		routers := []tsuru.AppRouter{}
+		if added {
+			polls++
+			status := "ready"
+			if polls == 1 {
+				status = "not ready"
+			}
+			routers = append(routers, tsuru.AppRouter{
+				Name:   "ingress-router",
+				Status: status,
+				Opts: map[string]interface{}{
+					"domain": "tsuru.io",
+					"nginx.ingress.kubernetes.io/proxy-body-size": "10m",
+				},
+			})
+		}
+		return c.JSON(http.StatusOK, routers)
+	})
+
+	fakeServer.POST("/1.5/apps/:app/routers", func(c echo.Context) error {
+		added = true
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.DELETE("/1.5/apps/:app/routers/:router", func(c echo.Context) error {
+		added = false
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_router.router"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_app_router" "router" {
+  app  = "app01"
+  name = "ingress-router"
+  options = {
+    "domain"                                      = "tsuru.io"
+    "nginx.ingress.kubernetes.io/proxy-body-size" = "10m"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					func(s *terraform.State) error {
+						assert.GreaterOrEqual(t, polls, 2)
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+resource "tsuru_app_router" "router" {
+  app  = "app01"
+  name = "ingress-router"
+  options = {
+    "domian" = "tsuru.io"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown options \[domian\] for router ingress-router of type api`),
+			},
+			{
+				// unknown options only warn on apply when not strict
+				Config: `
+resource "tsuru_app_router" "router" {
+  app            = "app01"
+  name           = "ingress-router"
+  strict_options = false
+  options = {
+    "domian" = "tsuru.io"
+  }
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestRouterOptionsWarnings(t *testing.T) {
+	options := map[string]interface{}{
+		"domian": "tsuru.io",
+		"nginx.ingress.kubernetes.io/proxy-body-size": "10m",
+	}
+
+	diags := routerOptionsWarnings(&tsuru.PlanRouter{Name: "ingress-router", Type: "api"}, options)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "unknown options [domian] for router ingress-router of type api")
+
+	diags = routerOptionsWarnings(&tsuru.PlanRouter{Name: "nginx", Type: "nginx-ingress"}, options)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, `unable to validate options [domian] of router nginx, the options of router type "nginx-ingress" are not known by the provider`)
+
+	assert.Empty(t, routerOptionsWarnings(&tsuru.PlanRouter{Name: "ingress-router", Type: "api"}, map[string]interface{}{"domain": "tsuru.io"}))
+	assert.Empty(t, routerOptionsWarnings(&tsuru.PlanRouter{Name: "nginx", Type: "nginx-ingress"}, map[string]interface{}{"nginx.ingress.kubernetes.io/proxy-body-size": "10m"}))
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceTsuruApplicationTeams() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Application Teams, authoritative list of the teams with access to an app, teams granted outside of it are revoked",
+		CreateContext: resourceTsuruApplicationTeamsSet,
+		ReadContext:   resourceTsuruApplicationTeamsRead,
+		UpdateContext: resourceTsuruApplicationTeamsSet,
+		DeleteContext: resourceTsuruApplicationTeamsDelete,
+		CustomizeDiff: resourceTsuruApplicationTeamsCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Description: "Application name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"teams": {
+				Type:        schema.TypeSet,
+				Description: "Teams with access to the app, must include the team owner of the app",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTsuruApplicationTeamsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("app") || !d.NewValueKnown("teams") {
+		return nil
+	}
+
+	provider := meta.(*tsuruProvider)
+	appName := d.Get("app").(string)
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		// the app may be created on the same plan
+		return nil
+	}
+
+	return checkTeamOwnerKept("app", appName, app.TeamOwner, setToStringSlice(d.Get("teams").(*schema.Set)))
+}
+
+func resourceTsuruApplicationTeamsSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Get("app").(string)
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	desired := setToStringSlice(d.Get("teams").(*schema.Set))
+	if err = checkTeamOwnerKept("app", appName, app.TeamOwner, desired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	grant, revoke := diffStringSets(app.Teams, desired)
+
+	// grants go first, so a failure halfway never leaves the app with fewer teams
+	for _, team := range grant {
+		if err = grantAppTeam(ctx, provider, appName, team, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, team := range revoke {
+		if err = revokeAppTeam(ctx, provider, appName, team, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(appName)
+
+	return resourceTsuruApplicationTeamsRead(ctx, d, meta)
+}
+
+func resourceTsuruApplicationTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Id()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	d.Set("app", appName)
+	d.Set("teams", app.Teams)
+
+	return nil
+}
+
+func resourceTsuruApplicationTeamsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	appName := d.Id()
+
+	unlock, err := provider.locks.lock(ctx, appLock(appName))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", appName, err)
+	}
+	defer unlock()
+
+	app, err := provider.getApp(ctx, appName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read app %s", appName)
+	}
+
+	for _, team := range app.Teams {
+		if team == app.TeamOwner {
+			continue
+		}
+		if err = revokeAppTeam(ctx, provider, appName, team, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func grantAppTeam(ctx context.Context, provider *tsuruProvider, app, team string, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		response, err := provider.TsuruClient.AppApi.AppTeamGrant(ctx, app, team)
+		// ignore teams already granted for this app
+		if response != nil && response.StatusCode == http.StatusConflict {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to grant team %s access to app %s", team, app)
+	}
+	return nil
+}
+
+func revokeAppTeam(ctx context.Context, provider *tsuruProvider, app, team string, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.AppApi.AppTeamRevoke(ctx, app, team)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to revoke team %s access to app %s", team, app)
+	}
+	return nil
+}
+
+func checkTeamOwnerKept(kind, name, teamOwner string, teams []string) error {
+	if teamOwner == "" {
+		return nil
+	}
+
+	for _, team := range teams {
+		if team == teamOwner {
+			return nil
+		}
+	}
+
+	return errors.Errorf("refusing to revoke the access of team %s, it is the team owner of %s %s, add it to teams", teamOwner, kind, name)
+}
+
+// diffStringSets returns the items of desired missing on current and the
+// items of current not on desired, both sorted.
+func diffStringSets(current, desired []string) (add []string, remove []string) {
+	currentSet := map[string]bool{}
+	for _, item := range current {
+		currentSet[item] = true
+	}
+
+	desiredSet := map[string]bool{}
+	for _, item := range desired {
+		desiredSet[item] = true
+		if !currentSet[item] {
+			add = append(add, item)
+		}
+	}
+
+	for _, item := range current {
+		if !desiredSet[item] {
+			remove = append(remove, item)
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+func setToStringSlice(set *schema.Set) []string {
+	result := []string{}
+	for _, item := range set.List() {
+		result = append(result, item.(string))
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruAppTeams(t *testing.T) {
+	fakeServer := echo.New()
+
+	teams := []string{"my-team", "granted-by-hand"}
+	calls := []string{}
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &tsuru.App{
+			Name:      c.Param("name"),
+			TeamOwner: "my-team",
+			Teams:     teams,
+		})
+	})
+
+	fakeServer.PUT("/1.0/apps/:app/teams/:team", func(c echo.Context) error {
+		team := c.Param("team")
+		calls = append(calls, "grant "+team)
+		teams = append(teams, team)
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:app/teams/:team", func(c echo.Context) error {
+		team := c.Param("team")
+		calls = append(calls, "revoke "+team)
+		newTeams := []string{}
+		for _, t := range teams {
+			if t != team {
+				newTeams = append(newTeams, t)
+			}
+		}
+		teams = newTeams
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app_teams.teams"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Equal(t, []string{"my-team"}, teams)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruAppTeams(`["my-team", "support-team"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "app", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "teams.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "teams.*", "my-team"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "teams.*", "support-team"),
+					func(s *terraform.State) error {
+						assert.Equal(t, []string{"grant support-team", "revoke granted-by-hand"}, calls)
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccResourceTsuruAppTeams(`["support-team"]`),
+				ExpectError: regexp.MustCompile("refusing to revoke the access of team my-team, it is the team owner of app app01"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruAppTeams(teams string) string {
+	return `
+	resource "tsuru_app_teams" "teams" {
+		app   = "app01"
+		teams = ` + teams + `
+	}
+`
+}
@@ -5,6 +5,8 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,8 +14,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -26,6 +31,13 @@ func TestAccResourceTsuruApp(t *testing.T) {
 		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
 	})
 
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.PlatformInfo{
+			Platform: tsuru.Platform{Name: c.Param("name")},
+			Images:   []string{"tsuru/python:v1", "tsuru/python:v2"},
+		})
+	})
+
 	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
 	})
@@ -217,6 +229,15 @@ func TestAccResourceTsuruApp(t *testing.T) {
 	})
 
 	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		app := tsuru.UpdateApp{}
+		c.Bind(&app)
+		// plan overrides are set right after the app creation
+		if app.Platform == "" {
+			require.NotNil(t, app.Planoverride.CpuBurst)
+			assert.Equal(t, 1.5, *app.Planoverride.CpuBurst)
+			assert.True(t, app.NoRestart)
+			return c.JSON(http.StatusOK, nil)
+		}
 		iterationCount++
 		return c.JSON(http.StatusOK, nil)
 	})
@@ -246,6 +267,7 @@ func TestAccResourceTsuruApp(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", "app01"),
 					resource.TestCheckResourceAttr(resourceName, "description", "my app description"),
 					resource.TestCheckResourceAttr(resourceName, "platform", "python"),
+					resource.TestCheckResourceAttr(resourceName, "platform_version", "2"),
 					resource.TestCheckResourceAttr(resourceName, "plan", "c2m4"),
 					resource.TestCheckResourceAttr(resourceName, "custom_cpu_burst", "1.5"),
 					resource.TestCheckResourceAttr(resourceName, "team_owner", "my-team"),
@@ -270,144 +292,1156 @@ func TestAccResourceTsuruApp(t *testing.T) {
 				),
 			},
 			{
-				Config:      testAccResourceTsuruApp_invalidProcessOrder(),
-				ExpectError: regexp.MustCompile("please, sort app processes alphabetically"),
+				// processes in any order are the same
+				Config:   testAccResourceTsuruApp_reversedProcessOrder(),
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func testAccResourceTsuruApp_basic() string {
-	return `
-	resource "tsuru_app" "app" {
-		name = "app01"
-		description = "my app description"
-		platform = "python"
-		plan = "c2m4"
-		custom_cpu_burst = "1.5"
-		team_owner = "my-team"
-		pool = "prod"
-		tags = ["tagA", "tagB"]
-		metadata {
-			labels = {
-				"label1" = "value1"
+func TestAccResourceTsuruApp_planOverride(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	var app *tsuru.App
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		app = &tsuru.App{
+			Name:      input.Name,
+			Platform:  input.Platform,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			TeamOwner: input.TeamOwner,
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	updates := []tsuru.PlanOverride{}
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		update := tsuru.UpdateApp{}
+		c.Bind(&update)
+		updates = append(updates, update.Planoverride)
+
+		override := &app.Plan.Override
+		if update.Planoverride.Memory != nil {
+			override.Memory = update.Planoverride.Memory
+			if *override.Memory == 0 {
+				override.Memory = nil
 			}
-			annotations = {
-				"annotation1": "some really long value"
+		}
+		if update.Planoverride.Cpumilli != nil {
+			override.Cpumilli = update.Planoverride.Cpumilli
+			if *override.Cpumilli == 0 {
+				override.Cpumilli = nil
 			}
 		}
-
-		process {
-			name = "web"
-			plan = "c2m2"
-			metadata {
-				labels = {
-					"weblabel" = "value"
-				}
-				annotations = {
-					"webannotation": "nice"
-				}
+		if update.Planoverride.CpuBurst != nil {
+			override.CpuBurst = update.Planoverride.CpuBurst
+			if *override.CpuBurst == 0 {
+				override.CpuBurst = nil
 			}
 		}
+		return c.JSON(http.StatusOK, nil)
+	})
 
-		process {
-			name = "worker"
-
-			metadata {
-				labels = {
-					"workerlabel" = "value"
-				}
-				annotations = {
-					"workerannotation": "nice"
-				}
-			}
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		if app == nil {
+			return c.JSON(http.StatusNotFound, nil)
 		}
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		app = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
 	}
-`
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app.app"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruApp_planOverride(`
+		plan_override {
+			memory    = "512Mi"
+			cpu       = "500m"
+			cpu_burst = 1.2
+		}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.memory", "512Mi"),
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.cpu", "500m"),
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.cpu_burst", "1.2"),
+					func(s *terraform.State) error {
+						require.Len(t, updates, 1)
+						assert.Equal(t, int64(512*1024*1024), *updates[0].Memory)
+						assert.Equal(t, 500, *updates[0].Cpumilli)
+						assert.Equal(t, 1.2, *updates[0].CpuBurst)
+						return nil
+					},
+				),
+			},
+			{
+				// the same memory written in another unit is not a change
+				Config: testAccResourceTsuruApp_planOverride(`
+		plan_override {
+			memory    = "536870912"
+			cpu       = "500m"
+			cpu_burst = 1.2
+		}`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccResourceTsuruApp_planOverride(`
+		plan_override {
+			memory = "1Gi"
+		}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.memory", "1Gi"),
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.cpu", ""),
+					resource.TestCheckResourceAttr(resourceName, "plan_override.0.cpu_burst", "0"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruApp_planOverride(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "plan_override.#", "0"),
+					func(s *terraform.State) error {
+						last := updates[len(updates)-1]
+						assert.Equal(t, int64(0), *last.Memory)
+						assert.Equal(t, 0, *last.Cpumilli)
+						assert.Equal(t, float64(0), *last.CpuBurst)
+						assert.Equal(t, tsuru.PlanOverride{}, app.Plan.Override)
+						return nil
+					},
+				),
+			},
+		},
+	})
 }
 
-func testAccResourceTsuruApp_metadataAfterUpdate() string {
-	return `
-	resource "tsuru_app" "app" {
-		name = "app01"
-		description = "my app description"
-		platform = "python"
-		plan = "c2m4"
-		team_owner = "my-team"
-		pool = "prod"
-		tags = ["tagA", "tagB"]
-		metadata {
-			labels = {
-				"label1" = "value1"
-				"label3" = "value3"
+func TestAccResourceTsuruApp_state(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.PlatformInfo{
+			Platform: tsuru.Platform{Name: c.Param("name")},
+			Images:   []string{"tsuru/python:v1", "tsuru/python:v2"},
+		})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	var app *tsuru.App
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		// deployed right after the creation
+		app = &tsuru.App{
+			Name:      input.Name,
+			Platform:  input.Platform,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			TeamOwner: input.TeamOwner,
+			Deploys:   1,
+			Units: []tsuru.Unit{
+				{Name: "web-1", Processname: "web", Status: "started"},
+				{Name: "worker-1", Processname: "worker", Status: "started"},
+			},
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	updates := 0
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		update := tsuru.UpdateApp{}
+		c.Bind(&update)
+		updates++
+		for _, process := range update.Processes {
+			if process.Plan != "$default" {
+				app.Processes = append(app.Processes, process)
 			}
 		}
+		return c.JSON(http.StatusOK, nil)
+	})
 
-		process {
-			name = "web"
-			plan = "c2m2"
-			metadata {
-				labels = {
-					"weblabel" = "value"
-				}
-				annotations = {
-					"webannotation": "nice"
+	actions := []string{}
+	lifecycle := func(status string) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			input := tsuru.AppStartStop{}
+			c.Bind(&input)
+			actions = append(actions, status+" "+input.Process)
+			for i, unit := range app.Units {
+				if input.Process == "" || input.Process == unit.Processname {
+					app.Units[i].Status = status
 				}
 			}
+			c.Response().Header().Set("Content-Type", "application/x-json-stream")
+			return c.String(http.StatusOK, `{"Message":"`+status+` units\n"}`+"\n")
 		}
+	}
+	fakeServer.POST("/1.0/apps/:name/start", lifecycle("started"))
+	fakeServer.POST("/1.0/apps/:name/stop", lifecycle("stopped"))
 
-		process {
-			name = "worker"
+	fakeServer.GET("/1.1/events", func(c echo.Context) error {
+		assert.Equal(t, "app01", c.QueryParam("target.value"))
+		return c.JSON(http.StatusOK, []tsuru.Event{{UniqueID: "evt-1"}})
+	})
+	fakeServer.GET("/1.1/events/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Event{UniqueID: c.Param("id")})
+	})
 
-			metadata {
-				labels = {
-					"workerlabel" = "value"
-				}
-				annotations = {
-					"workerannotation": "nice"
-				}
-			}
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		if app == nil {
+			return c.JSON(http.StatusNotFound, nil)
 		}
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		app = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
 	}
-`
-}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
 
-func testAccResourceTsuruApp_invalidProcessOrder() string {
-	return `
-	resource "tsuru_app" "app" {
-		name = "app01"
-		description = "my app description"
-		platform = "python"
-		plan = "c2m4"
-		team_owner = "my-team"
-		pool = "prod"
-		tags = ["tagA", "tagB"]
+	resourceName := "tsuru_app.app"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruApp_state(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "state", "started"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruApp_state(`
+		state = "stopped"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "stopped"),
+					func(s *terraform.State) error {
+						assert.Equal(t, 0, updates)
+						assert.Equal(t, []string{"stopped "}, actions)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTsuruApp_state(`
+		state = "started"
 
 		process {
-			name = "worker"
-			
-			metadata {
-				labels = {
-					"workerlabel" = "value"
-				}
-				annotations = {
-					"workerannotation" = "nice"
-				}
-			}
-		}
+			name  = "worker"
+			plan  = "c2m4"
+			state = "stopped"
+		}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "started"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "process.*", map[string]string{
+						"name":  "worker",
+						"state": "stopped",
+					}),
+					func(s *terraform.State) error {
+						assert.Equal(t, 1, updates)
+						assert.Equal(t, []string{"stopped ", "started ", "stopped worker"}, actions)
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					// worker started outside of terraform
+					app.Units[1].Status = "started"
+				},
+				Config: testAccResourceTsuruApp_state(`
+		state = "started"
 
 		process {
-			name = "web"
-			plan = "c2m2"
-			metadata {
-				labels = {
-					"weblabel" = "value"
-				}
-				annotations = {
-					"webannotation" = "nice"
-				}
-			}
+			name  = "worker"
+			plan  = "c2m4"
+			state = "stopped"
+		}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						assert.Equal(t, 1, updates)
+						assert.Equal(t, []string{"stopped ", "started ", "stopped worker", "stopped worker"}, actions)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_deletionProtection(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}, {Name: "dev"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	var app *tsuru.App
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		app = &tsuru.App{
+			Name:      input.Name,
+			Platform:  input.Platform,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			TeamOwner: input.TeamOwner,
 		}
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	updates := 0
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		update := tsuru.UpdateApp{}
+		c.Bind(&update)
+		updates++
+		app.Pool = update.Pool
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		if app == nil {
+			return c.JSON(http.StatusNotFound, nil)
+		}
+		return c.JSON(http.StatusOK, app)
+	})
+
+	deletes := 0
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		deletes++
+		app = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruApp_deletionProtection("dev", true, ""),
+				Check:  resource.TestCheckResourceAttr("tsuru_app.app", "deletion_protection", "true"),
+			},
+			{
+				Config:      testAccResourceTsuruApp_deletionProtection("dev", true, ""),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`app app01 has deletion protection enabled`),
+			},
+			{
+				Config: testAccResourceTsuruApp_deletionProtection("dev", false, ""),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, 0, updates)
+					assert.Equal(t, 0, deletes)
+					return nil
+				},
+			},
+			{
+				Config: testAccResourceTsuruApp_deletionProtection("prod", false, `protected_pools = ["prod"]`),
+			},
+			{
+				Config:      testAccResourceTsuruApp_deletionProtection("prod", false, `protected_pools = ["prod"]`),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`app app01 is in the protected pool prod`),
+			},
+			{
+				Config: testAccResourceTsuruApp_deletionProtection("prod", false, ""),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, 0, deletes)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_defaultTagsAndMetadata(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	var app *tsuru.App
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		assert.Equal(t, []string{"tagA", "cost-center:42"}, input.Tags)
+		assert.Equal(t, []tsuru.MetadataItem{{Name: "cost-center", Value: "42"}, {Name: "label1", Value: "value1"}}, input.Metadata.Labels)
+		assert.Equal(t, []tsuru.MetadataItem{{Name: "owner", Value: "platform"}}, input.Metadata.Annotations)
+		app = &tsuru.App{
+			Name:      input.Name,
+			Platform:  input.Platform,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			TeamOwner: input.TeamOwner,
+			Tags:      input.Tags,
+			Metadata:  input.Metadata,
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	updates := []tsuru.UpdateApp{}
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		update := tsuru.UpdateApp{}
+		c.Bind(&update)
+		updates = append(updates, update)
+		app.Tags = update.Tags
+		app.Metadata.Labels = updateMetadataItems(app.Metadata.Labels, update.Metadata.Labels)
+		app.Metadata.Annotations = updateMetadataItems(app.Metadata.Annotations, update.Metadata.Annotations)
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		if app == nil {
+			return c.JSON(http.StatusNotFound, nil)
+		}
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		app = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app.app"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruApp_defaultTagsAndMetadata(`["cost-center:42"]`, `{"owner" = "platform"}`, `["tagA"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.0", "tagA"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.label1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.annotations.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.0.labels.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.0.annotations.owner", "platform"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruApp_defaultTagsAndMetadata(`["cost-center:42"]`, `{"owner" = "platform"}`, `["tagA", "tagB"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "2"),
+					func(s *terraform.State) error {
+						require.Len(t, updates, 1)
+						assert.Equal(t, []string{"tagA", "tagB", "cost-center:42"}, updates[0].Tags)
+						// unchanged metadata is not sent
+						assert.Empty(t, updates[0].Metadata.Labels)
+						assert.Empty(t, updates[0].Metadata.Annotations)
+						return nil
+					},
+				),
+			},
+			{
+				// only the defaults of the provider change
+				Config: testAccResourceTsuruApp_defaultTagsAndMetadata(`["cost-center:43"]`, `{}`, `["tagA", "tagB"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.2", "cost-center:43"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.0.annotations.%", "0"),
+					func(s *terraform.State) error {
+						require.Len(t, updates, 2)
+						assert.Equal(t, []string{"tagA", "tagB", "cost-center:43"}, updates[1].Tags)
+						assert.Equal(t, []tsuru.MetadataItem{{Name: "owner", Value: "platform", Delete: true}}, updates[1].Metadata.Annotations)
+						assert.Empty(t, app.Metadata.Annotations)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// updateMetadataItems applies the items of an update as tsuru does, items
+// marked as deleted are removed.
+func updateMetadataItems(items, update []tsuru.MetadataItem) []tsuru.MetadataItem {
+	result := []tsuru.MetadataItem{}
+	for _, item := range items {
+		if !containsMetadataName(update, item.Name) {
+			result = append(result, item)
+		}
+	}
+	for _, item := range update {
+		if !item.Delete {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func TestAccResourceTsuruApp_providerDefaults(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	var app *tsuru.App
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		assert.Equal(t, "my-team", input.TeamOwner)
+		assert.Equal(t, "prod", input.Pool)
+		assert.Equal(t, "c2m4", input.Plan)
+		app = &tsuru.App{
+			Name:      input.Name,
+			Platform:  input.Platform,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			TeamOwner: input.TeamOwner,
+		}
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		if app == nil {
+			return c.JSON(http.StatusNotFound, nil)
+		}
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		app = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app.app"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruApp_providerDefaults(`default_pool = "prod"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`team_owner is required, set it on the resource or default_team_owner on the provider`),
+			},
+			{
+				Config: testAccResourceTsuruApp_providerDefaults(`
+		default_team_owner = "my-team"
+		default_pool       = "prod"
+		default_plan       = "c2m4"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "team_owner", "my-team"),
+					resource.TestCheckResourceAttr(resourceName, "pool", "prod"),
+					resource.TestCheckResourceAttr(resourceName, "plan", "c2m4"),
+				),
+			},
+			{
+				// existing apps keep their values
+				Config: testAccResourceTsuruApp_providerDefaults(`
+		default_team_owner = "other-team"
+		default_pool       = "dev"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_poolConstraints(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{
+			Name: "prod",
+			Allowed: map[string][]string{
+				"team": {"team-a", "team-b"},
+				"plan": {"c2m4"},
+			},
+		}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}, {Name: "c4m8"}})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruApp_poolConstraints("my-team", "c2m4"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`team my-team is not allowed in pool prod, allowed teams are \[team-a,team-b\]`),
+			},
+			{
+				Config:      testAccResourceTsuruApp_poolConstraints("team-a", "c4m8"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`plan c4m8 is not allowed in pool prod, allowed plans are \[c2m4\]`),
+			},
+			{
+				Config:      testAccResourceTsuruApp_poolConstraints("team-a", "c8m16"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid plan: c8m16 available plans are \[c2m4,c4m8\]`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_invalidPlatformVersion(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.PlatformInfo{
+			Platform: tsuru.Platform{Name: c.Param("name")},
+			Images:   []string{"tsuru/python:v1", "registry.io/tsuru/python:v2", "tsuru/python:v2"},
+		})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruApp_platform("python:3.99"),
+				ExpectError: regexp.MustCompile(`invalid platform version: python:3.99 available versions of python are \[1,2\]`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_platformVersion(t *testing.T) {
+	fakeServer := echo.New()
+
+	images := []string{"tsuru/python:v1", "tsuru/python:v2"}
+	app := tsuru.App{Name: "app01", Platform: "python", Plan: tsuru.Plan{Name: "c2m4"}, TeamOwner: "my-team", Pool: "prod", Deploys: 1}
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.PlatformInfo{Platform: tsuru.Platform{Name: c.Param("name")}, Images: images})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}})
+	})
+
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.AppCreateResponse{Status: "created"})
+	})
+
+	fakeServer.PUT("/1.0/apps/:name", func(c echo.Context) error {
+		input := tsuru.InputApp{}
+		c.Bind(&input)
+		app.Platform = input.Platform
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, app)
+	})
+
+	fakeServer.DELETE("/1.0/apps/:name", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_app.app"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruApp_platform("python"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "platform_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "deploys", "1"),
+				),
+			},
+			{
+				// the platform was rebuilt, the app runs the old image until it is deployed
+				PreConfig: func() { images = append(images, "tsuru/python:v3") },
+				Config:    testAccResourceTsuruApp_platform("python"),
+				Check:     resource.TestCheckResourceAttr(resourceName, "platform_version", "2"),
+			},
+			{
+				PreConfig: func() { app.Deploys++ },
+				Config:    testAccResourceTsuruApp_platform("python"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "platform_version", "3"),
+					resource.TestCheckResourceAttr(resourceName, "deploys", "2"),
+				),
+			},
+			{
+				Config: testAccResourceTsuruApp_platform("python:1"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "platform_version", "1"),
+			},
+			{
+				Config: testAccResourceTsuruApp_platform("python"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "platform_version", "3"),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruApp_rejected(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/platforms", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Platform{{Name: "python"}})
+	})
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c2m4"}, {Name: "c8m16"}})
+	})
+
+	creates := 0
+	fakeServer.POST("/1.0/apps", func(c echo.Context) error {
+		creates++
+		if creates == 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"Message": `plan "c8m16" is not enabled for pool prod`})
+		}
+		return c.String(http.StatusForbidden, "You don't have permission to do this action\n")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruApp_poolConstraints("my-team", "c8m16"),
+				ExpectError: regexp.MustCompile(`(?s)unable to create app app01.*plan = "c8m16".*status code 400: plan "c8m16" is not enabled`),
+			},
+			{
+				Config:      testAccResourceTsuruApp_poolConstraints("my-team", "c2m4"),
+				ExpectError: regexp.MustCompile(`(?s)status code 403: You don't have permission.*not allowed to do this action`),
+			},
+		},
+	})
+}
+
+func TestPinnedPlatformVersion(t *testing.T) {
+	assert.Equal(t, "", pinnedPlatformVersion("python"))
+	assert.Equal(t, "", pinnedPlatformVersion("python:latest"))
+	assert.Equal(t, "3", pinnedPlatformVersion("python:3"))
+	assert.Equal(t, "2", pinnedPlatformVersion("python:v2"))
+}
+
+func TestPlatformVersions(t *testing.T) {
+	assert.Equal(t, []int{}, platformVersions(nil))
+	assert.Equal(t, []int{1, 2, 10}, platformVersions([]string{
+		"tsuru/python:v10",
+		"tsuru/python:v1",
+		"localhost:5000/tsuru/python:v2",
+		"localhost:5000/tsuru/python",
+		"tsuru/python:latest",
+		"tsuru/python:v2",
+	}))
+}
+
+func testAccResourceTsuruApp_planOverride(planOverride string) string {
+	return fmt.Sprintf(`
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+%s
+	}
+`, planOverride)
+}
+
+func testAccResourceTsuruApp_state(state string) string {
+	return fmt.Sprintf(`
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+%s
+	}
+`, state)
+}
+
+func testAccResourceTsuruApp_deletionProtection(pool string, deletionProtection bool, providerConfig string) string {
+	return fmt.Sprintf(`
+	provider "tsuru" {
+		%s
+	}
+
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "%s"
+		deletion_protection = %t
+	}
+`, providerConfig, pool, deletionProtection)
+}
+
+func testAccResourceTsuruApp_defaultTagsAndMetadata(defaultTags, defaultAnnotations, tags string) string {
+	return fmt.Sprintf(`
+	provider "tsuru" {
+		default_tags = %s
+		default_metadata {
+			labels = {
+				"cost-center" = "42"
+			}
+			annotations = %s
+		}
+	}
+
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+		tags = %s
+		metadata {
+			labels = {
+				"label1" = "value1"
+			}
+		}
+	}
+`, defaultTags, defaultAnnotations, tags)
+}
+
+func testAccResourceTsuruApp_providerDefaults(providerConfig string) string {
+	return fmt.Sprintf(`
+	provider "tsuru" {
+		%s
+	}
+
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+	}
+`, providerConfig)
+}
+
+func testAccResourceTsuruApp_poolConstraints(team, plan string) string {
+	return fmt.Sprintf(`
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "python"
+		plan = "%s"
+		team_owner = "%s"
+		pool = "prod"
+	}
+`, plan, team)
+}
+
+func testAccResourceTsuruApp_platform(platform string) string {
+	return fmt.Sprintf(`
+	resource "tsuru_app" "app" {
+		name = "app01"
+		platform = "%s"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+	}
+`, platform)
+}
+
+func testAccResourceTsuruApp_basic() string {
+	return `
+	resource "tsuru_app" "app" {
+		name = "app01"
+		description = "my app description"
+		platform = "python"
+		plan = "c2m4"
+		custom_cpu_burst = "1.5"
+		team_owner = "my-team"
+		pool = "prod"
+		tags = ["tagA", "tagB"]
+		metadata {
+			labels = {
+				"label1" = "value1"
+			}
+			annotations = {
+				"annotation1": "some really long value"
+			}
+		}
+
+		process {
+			name = "web"
+			plan = "c2m2"
+			metadata {
+				labels = {
+					"weblabel" = "value"
+				}
+				annotations = {
+					"webannotation": "nice"
+				}
+			}
+		}
+
+		process {
+			name = "worker"
+
+			metadata {
+				labels = {
+					"workerlabel" = "value"
+				}
+				annotations = {
+					"workerannotation": "nice"
+				}
+			}
+		}
+	}
+`
+}
+
+func testAccResourceTsuruApp_metadataAfterUpdate() string {
+	return `
+	resource "tsuru_app" "app" {
+		name = "app01"
+		description = "my app description"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+		tags = ["tagA", "tagB"]
+		metadata {
+			labels = {
+				"label1" = "value1"
+				"label3" = "value3"
+			}
+		}
+
+		process {
+			name = "web"
+			plan = "c2m2"
+			metadata {
+				labels = {
+					"weblabel" = "value"
+				}
+				annotations = {
+					"webannotation": "nice"
+				}
+			}
+		}
+
+		process {
+			name = "worker"
+
+			metadata {
+				labels = {
+					"workerlabel" = "value"
+				}
+				annotations = {
+					"workerannotation": "nice"
+				}
+			}
+		}
+	}
+`
+}
+
+func testAccResourceTsuruApp_reversedProcessOrder() string {
+	return `
+	resource "tsuru_app" "app" {
+		name = "app01"
+		description = "my app description"
+		platform = "python"
+		plan = "c2m4"
+		team_owner = "my-team"
+		pool = "prod"
+		tags = ["tagA", "tagB"]
+		metadata {
+			labels = {
+				"label1" = "value1"
+				"label3" = "value3"
+			}
+		}
+
+		process {
+			name = "worker"
+
+			metadata {
+				labels = {
+					"workerlabel" = "value"
+				}
+				annotations = {
+					"workerannotation" = "nice"
+				}
+			}
+		}
+
+		process {
+			name = "web"
+			plan = "c2m2"
+			metadata {
+				labels = {
+					"weblabel" = "value"
+				}
+				annotations = {
+					"webannotation" = "nice"
+				}
+			}
+		}
+	}
+`
+}
+
+func TestResourceTsuruApplicationStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"name": "app01",
+		"process": []interface{}{
+			map[string]interface{}{"name": "worker", "plan": ""},
+			map[string]interface{}{"name": "web", "plan": "c2m2"},
+		},
+	}
+
+	v1, err := resourceTsuruApplicationStateUpgradeV0(context.Background(), v0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name": "app01",
+		"process": []interface{}{
+			map[string]interface{}{"name": "web", "plan": "c2m2"},
+			map[string]interface{}{"name": "worker", "plan": ""},
+		},
+	}, v1)
+}
+
+func TestResourceTsuruApplicationV0(t *testing.T) {
+	v0 := resourceTsuruApplicationV0()
+	require.NoError(t, v0.InternalValidate(nil, true))
+	assert.Equal(t, schema.TypeList, v0.Schema["process"].Type)
+
+	// added after the version bump
+	for _, attr := range []string{"platform_version", "plan_override", "state", "deletion_protection"} {
+		assert.NotContains(t, v0.Schema, attr)
 	}
-    `
 }
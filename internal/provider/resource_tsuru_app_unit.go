@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -23,6 +22,7 @@ func resourceTsuruApplicationUnits() *schema.Resource {
 		ReadContext:   resourceTsuruApplicationUnitsRead,
 		UpdateContext: resourceTsuruApplicationUnitsUpdate,
 		DeleteContext: resourceTsuruApplicationUnitsDelete,
+		CustomizeDiff: resourceTsuruApplicationUnitsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -56,6 +56,21 @@ func resourceTsuruApplicationUnits() *schema.Resource {
 	}
 }
 
+func resourceTsuruApplicationUnitsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("units_count") {
+		return nil
+	}
+
+	for _, key := range []string{"app", "process", "units_count"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	provider := meta.(*tsuruProvider)
+	return checkAppUnitQuota(ctx, provider, d.Get("app").(string), d.Get("process").(string), d.Get("units_count").(int))
+}
+
 func resourceTsuruApplicationUnitsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
@@ -71,9 +86,15 @@ func resourceTsuruApplicationUnitsCreate(ctx context.Context, d *schema.Resource
 
 	baseID := []string{app, process}
 
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
 	curUnits, err := countUnits(ctx, provider, app, process, version)
 	if err != nil {
-		return diag.Errorf("Unable to read app %s: %v", app, err)
+		return tsuruDiagnostics(err, "Unable to read app %s", app)
 	}
 
 	delta := units - curUnits
@@ -92,22 +113,13 @@ func resourceTsuruApplicationUnitsCreate(ctx context.Context, d *schema.Resource
 		return diag.Errorf("App has more running units for process %s than defined, update your tf file", process)
 	} else if delta > 0 {
 
-		err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-			_, err = provider.TsuruClient.AppApi.UnitsAdd(ctx, app, deltaRequest)
-			if err != nil {
-				var apiError tsuru_client.GenericOpenAPIError
-				if errors.As(err, &apiError) {
-					if isRetryableError(apiError.Body()) {
-						return resource.RetryableError(err)
-					}
-				}
-				return resource.NonRetryableError(errors.Errorf("unable to add units to %s %s: %v", app, process, err))
-			}
-			return nil
+		err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+			_, err := provider.TsuruClient.AppApi.UnitsAdd(ctx, app, deltaRequest)
+			return err
 		})
 
 		if err != nil {
-			return diag.FromErr(err)
+			return tsuruDiagnostics(err, "unable to add units to %s %s", app, process)
 		}
 	}
 
@@ -158,9 +170,15 @@ func resourceTsuruApplicationUnitsUpdate(ctx context.Context, d *schema.Resource
 		version = &v
 	}
 
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
 	curUnits, err := countUnits(ctx, provider, app, process, version)
 	if err != nil {
-		return diag.Errorf("Unable to read app %s: %v", app, err)
+		return tsuruDiagnostics(err, "Unable to read app %s", app)
 	}
 
 	delta := units - curUnits
@@ -175,16 +193,22 @@ func resourceTsuruApplicationUnitsUpdate(ctx context.Context, d *schema.Resource
 
 	if delta < 0 {
 		deltaRequest.Units = strconv.Itoa(-delta)
-		_, err = provider.TsuruClient.AppApi.UnitsRemove(ctx, app, deltaRequest)
+		err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+			_, err := provider.TsuruClient.AppApi.UnitsRemove(ctx, app, deltaRequest)
+			return err
+		})
 		if err != nil {
-			return diag.Errorf("unable to remove units from %s %s: %v", app, process, err)
+			return tsuruDiagnostics(err, "unable to remove units from %s %s", app, process)
 		}
 
 	} else if delta > 0 {
 		deltaRequest.Units = strconv.Itoa(delta)
-		_, err = provider.TsuruClient.AppApi.UnitsAdd(ctx, app, deltaRequest)
+		err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+			_, err := provider.TsuruClient.AppApi.UnitsAdd(ctx, app, deltaRequest)
+			return err
+		})
 		if err != nil {
-			return diag.Errorf("unable to add units to %s %s: %v", app, process, err)
+			return tsuruDiagnostics(err, "unable to add units to %s %s", app, process)
 		}
 	}
 
@@ -214,21 +238,30 @@ func resourceTsuruApplicationUnitsDelete(ctx context.Context, d *schema.Resource
 		deltaRequest.Version = vStr
 	}
 
-	_, err := provider.TsuruClient.AppApi.UnitsRemove(ctx, app, deltaRequest)
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
+		_, err := provider.TsuruClient.AppApi.UnitsRemove(ctx, app, deltaRequest)
+		return err
+	})
 	if err != nil {
-		return diag.Errorf("unable to remove units from %s %s: %v", app, process, err)
+		return tsuruDiagnostics(err, "unable to remove units from %s %s", app, process)
 	}
 
 	return nil
 }
 
 func countUnits(ctx context.Context, provider *tsuruProvider, appName, process string, version *int) (int, error) {
-	app, _, err := provider.TsuruClient.AppApi.AppGet(ctx, appName)
+	app, err := provider.getApp(ctx, appName)
 	if err != nil {
 		if isNotFoundError(err) {
 			return 0, nil
 		}
-		return 0, errors.Errorf("unable to read app %s: %v", app.Name, err)
+		return 0, errors.Errorf("unable to read app %s: %v", appName, err)
 	}
 
 	units := 0
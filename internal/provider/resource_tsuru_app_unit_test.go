@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -59,6 +60,10 @@ func TestAccResourceTsuruAppUnit(t *testing.T) {
 		return c.JSON(http.StatusOK, app)
 	})
 
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: 10, Inuse: 3})
+	})
+
 	fakeServer.PUT("/1.0/apps/:app/units", func(c echo.Context) error {
 		app := c.Param("app")
 		delta := tsuru.UnitsDelta{}
@@ -105,6 +110,71 @@ func TestAccResourceTsuruAppUnit(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruAppUnitQuotaExceeded(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/apps/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &tsuru.App{
+			Name: c.Param("name"),
+			Units: []tsuru.Unit{
+				{Processname: "web"},
+				{Processname: "web"},
+				{Processname: "web"},
+				{Processname: "worker"},
+				{Processname: "worker"},
+			},
+		})
+	})
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Quota{Limit: 6, Inuse: 5})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruAppUnit_basic(),
+				ExpectError: regexp.MustCompile("5 units of process web exceed the unit quota of app app01: limit is 6, 2 units in use by other processes"),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruAppUnitQuotaUnavailable(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/apps/:app/quota", func(c echo.Context) error {
+		return c.String(http.StatusForbidden, "You don't have permission to do this action\n")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruAppUnit_basic(),
+				ExpectError: regexp.MustCompile(`unable to check the unit quota of app app01: tsuru API responded with status code 403`),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruAppUnit_basic() string {
 	return `
 	resource "tsuru_app_unit" "units" {
@@ -19,6 +19,7 @@ func resourceTsuruCertificateIssuer() *schema.Resource {
 		Description:   "Set a issuer to generate certificates to a tsuru application",
 		CreateContext: resourceTsuruCertificateIssuerSet,
 		ReadContext:   resourceTsuruCertificateIssuerRead,
+		UpdateContext: resourceTsuruCertificateIssuerUpdate,
 		DeleteContext: resourceTsuruCertificateIssuerUnset,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -50,6 +51,13 @@ func resourceTsuruCertificateIssuer() *schema.Resource {
 				ForceNew:    true,
 			},
 
+			"wait_for_ready": {
+				Type:        schema.TypeBool,
+				Description: "Wait until the certificate is issued",
+				Optional:    true,
+				Default:     false,
+			},
+
 			"router": {
 				Type:        schema.TypeList,
 				Description: "Routers that are using the certificate",
@@ -80,17 +88,45 @@ func resourceTsuruCertificateIssuerSet(ctx context.Context, d *schema.ResourceDa
 	cname := d.Get("cname").(string)
 	issuer := d.Get("issuer").(string)
 
-	_, err := provider.TsuruClient.AppApi.AppSetCertIssuer(context.Background(), app, tsuru.CertIssuerSetData{
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	_, err = provider.TsuruClient.AppApi.AppSetCertIssuer(ctx, app, tsuru.CertIssuerSetData{
 		Cname:  cname,
 		Issuer: issuer,
 	})
 
 	if err != nil {
-		return diag.Errorf("unable to set certificate issuer: %v", err)
+		return tsuruDiagnostics(err, "unable to set certificate issuer")
 	}
 
 	d.SetId(app + "::" + cname + "::" + issuer)
 
+	// other resources of the app don't wait for the certificate
+	unlock()
+
+	if d.Get("wait_for_ready").(bool) {
+		if err = waitAppCertificatesReady(ctx, provider, app, []string{cname}, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTsuruCertificateIssuerRead(ctx, d, meta)
+}
+
+func resourceTsuruCertificateIssuerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	if d.HasChange("wait_for_ready") && d.Get("wait_for_ready").(bool) {
+		err := waitAppCertificatesReady(ctx, provider, d.Get("app").(string), []string{d.Get("cname").(string)}, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceTsuruCertificateIssuerRead(ctx, d, meta)
 }
 
@@ -103,10 +139,16 @@ func resourceTsuruCertificateIssuerUnset(ctx context.Context, d *schema.Resource
 	app := parts[0]
 	cname := parts[1]
 
-	_, err = provider.TsuruClient.AppApi.AppUnsetCertIssuer(context.Background(), app, cname)
+	unlock, err := provider.locks.lock(ctx, appLock(app))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", app, err)
+	}
+	defer unlock()
+
+	_, err = provider.TsuruClient.AppApi.AppUnsetCertIssuer(ctx, app, cname)
 
 	if err != nil {
-		return diag.Errorf("unable to unset certificate issuer: %v", err)
+		return tsuruDiagnostics(err, "unable to unset certificate issuer")
 	}
 
 	return resourceTsuruCertificateIssuerRead(ctx, d, meta)
@@ -122,7 +164,7 @@ func resourceTsuruCertificateIssuerRead(ctx context.Context, d *schema.ResourceD
 	cname := parts[1]
 	issuer := parts[2]
 
-	certificates, _, err := provider.TsuruClient.AppApi.AppGetCertificates(context.Background(), app)
+	certificates, _, err := provider.TsuruClient.AppApi.AppGetCertificates(ctx, app)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -109,3 +109,76 @@ resource "tsuru_certificate_issuer" "cert" {
 }
 `, app, cname, issuer)
 }
+
+func TestAccTsuruCertificateIssuerWaitForReady(t *testing.T) {
+	fakeServer := echo.New()
+	fakeServer.PUT("/1.24/apps/:app/certissuer", func(c echo.Context) error {
+		return nil
+	})
+
+	fakeServer.DELETE("/1.24/apps/:app/certissuer", func(c echo.Context) error {
+		return nil
+	})
+
+	polls := 0
+	fakeServer.GET("/1.24/apps/:app/certificate", func(c echo.Context) error {
+		polls++
+		// the router only lists the cname after it reconciles the app
+		if polls == 1 {
+			return c.JSON(http.StatusOK, tsuru.AppCertificates{
+				Routers: map[string]tsuru.AppCertificatesRouters{
+					"https-router": {Cnames: map[string]tsuru.AppCertificatesCnames{}},
+				},
+			})
+		}
+
+		certificate := ""
+		if polls > 2 {
+			certificate = "123"
+		}
+
+		return c.JSON(http.StatusOK, tsuru.AppCertificates{
+			Routers: map[string]tsuru.AppCertificatesRouters{
+				"https-router": {
+					Cnames: map[string]tsuru.AppCertificatesCnames{
+						"my-cname.org": {
+							Issuer:      "lets-encrypt",
+							Certificate: certificate,
+						},
+					},
+				},
+			},
+		})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("method=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_certificate_issuer.cert"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_certificate_issuer" "cert" {
+	app            = "my-app"
+	cname          = "my-cname.org"
+	issuer         = "lets-encrypt"
+	wait_for_ready = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ready", "true"),
+					resource.TestCheckResourceAttr(resourceName, "certificate.0", "123"),
+				),
+			},
+		},
+	})
+}
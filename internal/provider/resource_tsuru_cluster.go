@@ -270,7 +270,7 @@ func resourceTsuruClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	_, err := provider.TsuruClient.ClusterApi.ClusterCreate(ctx, cluster)
 
 	if err != nil {
-		return diag.Errorf("Could not create tsuru cluster, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru cluster")
 	}
 
 	d.SetId(cluster.Name)
@@ -290,7 +290,7 @@ func resourceTsuruClusterRead(ctx context.Context, d *schema.ResourceData, meta
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("Could not read tsuru cluster, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru cluster")
 	}
 
 	d.Set("addresses", cluster.Addresses)
@@ -314,17 +314,24 @@ func resourceTsuruClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	cluster := clusterFromResourceData(d)
 
+	// tsuru_cluster_pool changes the pools of the cluster too
+	unlock, err := provider.locks.lock(ctx, clusterLock(d.Id()))
+	if err != nil {
+		return diag.Errorf("Could not lock tsuru cluster: %q, err: %s", d.Id(), err.Error())
+	}
+	defer unlock()
+
 	existentCluster, _, err := provider.TsuruClient.ClusterApi.ClusterInfo(ctx, d.Id())
 
 	if err != nil {
-		return diag.Errorf("Could not read tsuru cluster, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru cluster")
 	}
 	cluster.Pools = existentCluster.Pools
 
 	_, err = provider.TsuruClient.ClusterApi.ClusterUpdate(ctx, d.Id(), cluster)
 
 	if err != nil {
-		return diag.Errorf("Could not update tsuru cluster: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not update tsuru cluster %q", d.Id())
 	}
 
 	return resourceTsuruClusterRead(ctx, d, meta)
@@ -335,7 +342,7 @@ func resourceTsuruClusterDelete(ctx context.Context, d *schema.ResourceData, met
 
 	_, err := provider.TsuruClient.ClusterApi.ClusterDelete(ctx, d.Id())
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru cluster, err: %s", err.Error())
+		return tsuruDiagnostics(err, "Could not delete tsuru cluster")
 	}
 
 	return nil
@@ -6,11 +6,13 @@ package provider
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 )
 
 func resourceTsuruClusterPool() *schema.Resource {
@@ -45,28 +47,17 @@ func resourceTsuruClusterPool() *schema.Resource {
 
 func resourceTsuruClusterPoolSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
-	clusterName, poolName := getClusterAndPoolFromResource(d)
-
-	cluster, _, err := provider.TsuruClient.ClusterApi.ClusterInfo(ctx, clusterName)
+	clusterName, poolName, err := getClusterAndPoolFromResource(d)
 	if err != nil {
-		return diag.Errorf("Could not read tsuru cluster: %q, err: %s", clusterName, err.Error())
-	}
-	found := false
-	for _, foundPool := range cluster.Pools {
-		if foundPool == poolName {
-			found = true
-		}
-	}
-	if !found {
-		cluster.Pools = append(cluster.Pools, poolName)
+		return diag.FromErr(err)
 	}
 
-	_, err = provider.TsuruClient.ClusterApi.ClusterUpdate(ctx, clusterName, cluster)
+	err = updateClusterPools(ctx, provider, clusterName, poolName, true)
 	if err != nil {
-		return diag.Errorf("Could not update tsuru cluster: %q, err: %s", clusterName, err.Error())
+		return diag.FromErr(err)
 	}
 
-	d.SetId(clusterName + "/" + poolName)
+	d.SetId(createID([]string{clusterName, poolName}))
 
 	return resourceTsuruClusterPoolRead(ctx, d, meta)
 }
@@ -74,18 +65,14 @@ func resourceTsuruClusterPoolSet(ctx context.Context, d *schema.ResourceData, me
 func resourceTsuruClusterPoolUnset(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
-	clusterName, poolName := getClusterAndPoolFromResource(d)
-
-	cluster, _, err := provider.TsuruClient.ClusterApi.ClusterInfo(ctx, clusterName)
+	clusterName, poolName, err := getClusterAndPoolFromResource(d)
 	if err != nil {
-		return diag.Errorf("Could not read tsuru cluster: %q, err: %s", clusterName, err.Error())
+		return diag.FromErr(err)
 	}
 
-	cluster.Pools = removeItemFromSlice(cluster.Pools, poolName)
-
-	_, err = provider.TsuruClient.ClusterApi.ClusterUpdate(ctx, clusterName, cluster)
+	err = updateClusterPools(ctx, provider, clusterName, poolName, false)
 	if err != nil {
-		return diag.Errorf("Could not update tsuru cluster: %q, err: %s", clusterName, err.Error())
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -93,10 +80,10 @@ func resourceTsuruClusterPoolUnset(ctx context.Context, d *schema.ResourceData,
 
 func resourceTsuruClusterPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
-	parts := strings.SplitN(d.Id(), "/", 2)
-
-	clusterName := parts[0]
-	poolName := parts[1]
+	clusterName, poolName, err := parseClusterPoolID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	cluster, _, err := provider.TsuruClient.ClusterApi.ClusterInfo(ctx, clusterName)
 
@@ -106,7 +93,7 @@ func resourceTsuruClusterPoolRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err != nil {
-		return diag.Errorf("Could not read tsuru cluster: %q, err: %s", clusterName, err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru cluster %q", clusterName)
 	}
 
 	for _, foundPool := range cluster.Pools {
@@ -120,16 +107,71 @@ func resourceTsuruClusterPoolRead(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-func getClusterAndPoolFromResource(d *schema.ResourceData) (cluster, pool string) {
-	id := d.Id()
-	cluster = d.Get("cluster").(string)
-	pool = d.Get("pool").(string)
-	if id != "" {
-		parts := strings.SplitN(d.Id(), "/", 2)
-		cluster = parts[0]
-		pool = parts[1]
+// updateClusterPools adds or removes the pool from the cluster, tsuru has no
+// endpoint to change a single pool so the cluster is locked while its pools
+// are read and written back. The cluster is read again to check the change,
+// a write from outside the provider may still drop it.
+func updateClusterPools(ctx context.Context, provider *tsuruProvider, clusterName, poolName string, add bool) error {
+	unlock, err := provider.locks.lock(ctx, clusterLock(clusterName))
+	if err != nil {
+		return errors.Errorf("Could not lock tsuru cluster: %q, err: %s", clusterName, err.Error())
+	}
+	defer unlock()
+
+	cluster, _, err := provider.TsuruClient.ClusterApi.ClusterInfo(ctx, clusterName)
+	if err != nil {
+		return errors.Errorf("Could not read tsuru cluster: %q, err: %s", clusterName, err.Error())
+	}
+
+	if add {
+		if !slices.Contains(cluster.Pools, poolName) {
+			cluster.Pools = append(cluster.Pools, poolName)
+		}
+	} else {
+		cluster.Pools = removeItemFromSlice(cluster.Pools, poolName)
+	}
+
+	_, err = provider.TsuruClient.ClusterApi.ClusterUpdate(ctx, clusterName, cluster)
+	if err != nil {
+		return errors.Errorf("Could not update tsuru cluster: %q, err: %s", clusterName, err.Error())
+	}
+
+	cluster, _, err = provider.TsuruClient.ClusterApi.ClusterInfo(ctx, clusterName)
+	if err != nil {
+		return errors.Errorf("Could not read tsuru cluster: %q, err: %s", clusterName, err.Error())
+	}
+
+	if found := slices.Contains(cluster.Pools, poolName); found != add {
+		if add {
+			return errors.Errorf("Pool %q was not added to tsuru cluster %q, it may have been updated concurrently", poolName, clusterName)
+		}
+		return errors.Errorf("Pool %q was not removed from tsuru cluster %q, it may have been updated concurrently", poolName, clusterName)
+	}
+
+	return nil
+}
+
+func getClusterAndPoolFromResource(d *schema.ResourceData) (cluster, pool string, err error) {
+	if d.Id() != "" {
+		return parseClusterPoolID(d.Id())
 	}
-	return
+	return d.Get("cluster").(string), d.Get("pool").(string), nil
+}
+
+// parseClusterPoolID accepts IDs created with ID_SEPARATOR and the
+// cluster/pool IDs created by older versions.
+func parseClusterPoolID(id string) (cluster, pool string, err error) {
+	separator := ID_SEPARATOR
+	if !strings.Contains(id, ID_SEPARATOR) {
+		separator = "/"
+	}
+
+	parts := strings.SplitN(id, separator, 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("Invalid tsuru cluster pool ID %q, expected cluster%spool", id, ID_SEPARATOR)
+	}
+
+	return parts[0], parts[1], nil
 }
 
 func setClusterPoolNotFound(d *schema.ResourceData) {
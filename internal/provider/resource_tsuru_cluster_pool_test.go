@@ -9,9 +9,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,6 +76,144 @@ func TestAccTsuruClusterPool_basic(t *testing.T) {
 	})
 }
 
+func TestAccTsuruClusterPool_concurrent(t *testing.T) {
+	fakeServer := echo.New()
+
+	var mu sync.Mutex
+	pools := []string{"other-pool"}
+
+	fakeServer.POST("/1.4/provisioner/clusters/:cluster", func(c echo.Context) error {
+		p := &tsuru.Cluster{}
+		err := c.Bind(p)
+		require.NoError(t, err)
+
+		// widen the window between the read and the write of the pools
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		pools = p.Pools
+		return nil
+	})
+	fakeServer.GET("/1.8/provisioner/clusters/:cluster", func(c echo.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.JSON(http.StatusOK, tsuru.Cluster{
+			Name:  c.Param("cluster"),
+			Pools: append([]string{}, pools...),
+		})
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("method=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, []string{"other-pool"}, pools)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_cluster_pool" "cluster-pool" {
+	count   = 5
+	cluster = "my-cluster"
+	pool    = "pool-${count.index}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tsuru_cluster_pool.cluster-pool.0", "id", "my-cluster::pool-0"),
+					func(s *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+						sorted := append([]string{}, pools...)
+						sort.Strings(sorted)
+						assert.Equal(t, []string{"other-pool", "pool-0", "pool-1", "pool-2", "pool-3", "pool-4"}, sorted)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccTsuruClusterPool_import(t *testing.T) {
+	fakeServer := echo.New()
+	fakeServer.GET("/1.8/provisioner/clusters/:cluster", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.Cluster{
+			Name:  c.Param("cluster"),
+			Pools: []string{"other-pool", "my-pool"},
+		})
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("method=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	checkImported := func(states []*terraform.InstanceState) error {
+		require.Len(t, states, 1)
+		assert.Equal(t, "my-cluster", states[0].Attributes["cluster"])
+		assert.Equal(t, "my-pool", states[0].Attributes["pool"])
+		return nil
+	}
+
+	resourceName := "tsuru_cluster_pool.cluster-pool"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:           testAccTsuruClusterPoolConfig_basic(server.URL, "my-cluster", "my-pool"),
+				ResourceName:     resourceName,
+				ImportState:      true,
+				ImportStateId:    "my-cluster::my-pool",
+				ImportStateCheck: checkImported,
+			},
+			{
+				Config:           testAccTsuruClusterPoolConfig_basic(server.URL, "my-cluster", "my-pool"),
+				ResourceName:     resourceName,
+				ImportState:      true,
+				ImportStateId:    "my-cluster/my-pool",
+				ImportStateCheck: checkImported,
+			},
+		},
+	})
+}
+
+func TestParseClusterPoolID(t *testing.T) {
+	tests := []struct {
+		id      string
+		cluster string
+		pool    string
+		err     string
+	}{
+		{id: "my-cluster::my-pool", cluster: "my-cluster", pool: "my-pool"},
+		{id: "my-cluster/my-pool", cluster: "my-cluster", pool: "my-pool"},
+		{id: "my-cluster", err: `Invalid tsuru cluster pool ID "my-cluster", expected cluster::pool`},
+		{id: "my-cluster::", err: `Invalid tsuru cluster pool ID "my-cluster::", expected cluster::pool`},
+	}
+
+	for _, tt := range tests {
+		cluster, pool, err := parseClusterPoolID(tt.id)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.cluster, cluster)
+		assert.Equal(t, tt.pool, pool)
+	}
+}
+
 func testAccTsuruClusterPoolConfig_basic(fakeServer, cluster, pool string) string {
 	return fmt.Sprintf(`
 resource "tsuru_cluster_pool" "cluster-pool" {
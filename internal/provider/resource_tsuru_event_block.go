@@ -0,0 +1,237 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceTsuruEventBlock() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Event Block, blocks the matching events (e.g. app deploys) while it exists",
+		CreateContext: resourceTsuruEventBlockCreate,
+		ReadContext:   resourceTsuruEventBlockRead,
+		DeleteContext: resourceTsuruEventBlockDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "Prefix of the event kinds to block (e.g. app.deploy), all kinds are blocked when empty",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Description: "Name of the event owner to block (e.g. an user email or a team token)",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"target_type": {
+				Type:        schema.TypeString,
+				Description: "Type of the event target to block (e.g. app, pool, job)",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"target_value": {
+				Type:         schema.TypeString,
+				Description:  "Value of the event target to block (e.g. the app name)",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"target_type"},
+			},
+			"conditions": {
+				Type:        schema.TypeMap,
+				Description: "Extra conditions of the block (e.g. pool = \"prod\" blocks only apps on the prod pool)",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Description: "Reason shown to who has an event blocked",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"start_time": {
+				Type:        schema.TypeString,
+				Description: "When the block started",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type eventBlock struct {
+	ID         string
+	StartTime  time.Time
+	KindName   string
+	OwnerName  string
+	Target     eventBlockTarget
+	Conditions map[string]string
+	Reason     string
+	Active     bool
+}
+
+type eventBlockTarget struct {
+	Type  string
+	Value string
+}
+
+func resourceTsuruEventBlockCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	existing, err := listEventBlocks(ctx, provider)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to list event blocks")
+	}
+
+	values := url.Values{}
+	values.Set("KindName", d.Get("kind").(string))
+	values.Set("OwnerName", d.Get("owner").(string))
+	values.Set("Target.Type", d.Get("target_type").(string))
+	values.Set("Target.Value", d.Get("target_value").(string))
+	values.Set("Reason", d.Get("reason").(string))
+	for key, value := range d.Get("conditions").(map[string]interface{}) {
+		values.Set("Conditions."+key, value.(string))
+	}
+
+	resp, err := tsuruRequest(ctx, provider, http.MethodPost, "/1.3/events/blocks", "application/x-www-form-urlencoded", strings.NewReader(values.Encode()))
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to create event block")
+	}
+	resp.Body.Close()
+
+	// tsuru does not return the id of the new block, so it is the active one
+	// missing on the list taken before the creation with the same fields
+	blocks, err := listEventBlocks(ctx, provider)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to list event blocks")
+	}
+
+	knownIDs := map[string]bool{}
+	for _, block := range existing {
+		knownIDs[block.ID] = true
+	}
+
+	created := []string{}
+	for _, block := range blocks {
+		if !knownIDs[block.ID] && eventBlockMatches(block, d) {
+			created = append(created, block.ID)
+		}
+	}
+
+	switch len(created) {
+	case 0:
+		return diag.Errorf("unable to find the created event block")
+	case 1:
+		d.SetId(created[0])
+		return resourceTsuruEventBlockRead(ctx, d, meta)
+	default:
+		return diag.Errorf("unable to tell which event block was created, blocks %s were created with the same fields at the same time: import the one to be managed and delete the others", strings.Join(created, ", "))
+	}
+}
+
+// eventBlockMatches returns whether block has every field configured on d.
+func eventBlockMatches(block eventBlock, d *schema.ResourceData) bool {
+	if block.KindName != d.Get("kind").(string) ||
+		block.OwnerName != d.Get("owner").(string) ||
+		block.Target.Type != d.Get("target_type").(string) ||
+		block.Target.Value != d.Get("target_value").(string) ||
+		block.Reason != d.Get("reason").(string) {
+		return false
+	}
+
+	conditions := d.Get("conditions").(map[string]interface{})
+	if len(block.Conditions) != len(conditions) {
+		return false
+	}
+	for key, value := range conditions {
+		if block.Conditions[key] != value.(string) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resourceTsuruEventBlockRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	id := d.Id()
+
+	blocks, err := listEventBlocks(ctx, provider)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read event block %s", id)
+	}
+
+	for _, block := range blocks {
+		if block.ID != id {
+			continue
+		}
+
+		d.Set("kind", block.KindName)
+		d.Set("owner", block.OwnerName)
+		d.Set("target_type", block.Target.Type)
+		d.Set("target_value", block.Target.Value)
+		d.Set("conditions", block.Conditions)
+		d.Set("reason", block.Reason)
+		d.Set("start_time", block.StartTime.Format(time.RFC3339))
+		return nil
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceTsuruEventBlockDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	id := d.Id()
+
+	resp, err := tsuruRequest(ctx, provider, http.MethodDelete, "/1.3/events/blocks/"+url.PathEscape(id), "", nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to delete event block %s", id)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// listEventBlocks returns the active event blocks, the generated client has
+// no support for event blocks.
+func listEventBlocks(ctx context.Context, provider *tsuruProvider) ([]eventBlock, error) {
+	resp, err := tsuruRequest(ctx, provider, http.MethodGet, "/1.3/events/blocks?active=true", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	blocks := []eventBlock{}
+	if resp.StatusCode == http.StatusNoContent {
+		return blocks, nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&blocks); err != nil {
+		return nil, errors.Errorf("unable to decode event blocks: %v", err)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccResourceTsuruEventBlock(t *testing.T) {
+	fakeServer := echo.New()
+
+	blocks := []eventBlock{
+		{ID: "65f0000000000000000000aa", KindName: "app.deploy", Reason: "someone else block", Active: true},
+	}
+
+	fakeServer.GET("/1.3/events/blocks", func(c echo.Context) error {
+		assert.Equal(t, "true", c.QueryParam("active"))
+		active := []eventBlock{}
+		for _, block := range blocks {
+			if block.Active {
+				active = append(active, block)
+			}
+		}
+		if len(active) == 0 {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, active)
+	})
+
+	fakeServer.POST("/1.3/events/blocks", func(c echo.Context) error {
+		assert.Equal(t, "app.deploy", c.FormValue("KindName"))
+		assert.Equal(t, "", c.FormValue("OwnerName"))
+		assert.Equal(t, "app", c.FormValue("Target.Type"))
+		assert.Equal(t, "app01", c.FormValue("Target.Value"))
+		assert.Equal(t, "prod", c.FormValue("Conditions.pool"))
+		assert.Equal(t, "release freeze", c.FormValue("Reason"))
+
+		blocks = append(blocks, eventBlock{
+			ID:         "65f0000000000000000000bb",
+			StartTime:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+			KindName:   c.FormValue("KindName"),
+			Target:     eventBlockTarget{Type: c.FormValue("Target.Type"), Value: c.FormValue("Target.Value")},
+			Conditions: map[string]string{"pool": c.FormValue("Conditions.pool")},
+			Reason:     c.FormValue("Reason"),
+			Active:     true,
+		})
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.DELETE("/1.3/events/blocks/:uuid", func(c echo.Context) error {
+		for i := range blocks {
+			if blocks[i].ID == c.Param("uuid") && blocks[i].Active {
+				blocks[i].Active = false
+				return c.NoContent(http.StatusOK)
+			}
+		}
+		return c.String(http.StatusNotFound, "active event block not found")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_event_block.freeze"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.True(t, blocks[0].Active)
+			assert.False(t, blocks[1].Active)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruEventBlock_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", "65f0000000000000000000bb"),
+					resource.TestCheckResourceAttr(resourceName, "kind", "app.deploy"),
+					resource.TestCheckResourceAttr(resourceName, "target_type", "app"),
+					resource.TestCheckResourceAttr(resourceName, "target_value", "app01"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.pool", "prod"),
+					resource.TestCheckResourceAttr(resourceName, "reason", "release freeze"),
+					resource.TestCheckResourceAttr(resourceName, "start_time", "2026-10-01T12:00:00Z"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruEventBlock_ambiguous(t *testing.T) {
+	fakeServer := echo.New()
+
+	blocks := []eventBlock{}
+
+	fakeServer.GET("/1.3/events/blocks", func(c echo.Context) error {
+		if len(blocks) == 0 {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, blocks)
+	})
+
+	fakeServer.POST("/1.3/events/blocks", func(c echo.Context) error {
+		// another apply created the same block at the same time
+		for _, id := range []string{"65f0000000000000000000bb", "65f0000000000000000000cc"} {
+			blocks = append(blocks, eventBlock{
+				ID:         id,
+				KindName:   c.FormValue("KindName"),
+				Target:     eventBlockTarget{Type: c.FormValue("Target.Type"), Value: c.FormValue("Target.Value")},
+				Conditions: map[string]string{"pool": c.FormValue("Conditions.pool")},
+				Reason:     c.FormValue("Reason"),
+				Active:     true,
+			})
+		}
+		// a block of other fields is not a candidate
+		blocks = append(blocks, eventBlock{ID: "65f0000000000000000000dd", KindName: "app.deploy", Reason: "release freeze", Active: true})
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruEventBlock_basic(),
+				ExpectError: regexp.MustCompile(`blocks 65f0000000000000000000bb, 65f0000000000000000000cc were\s+created`),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruEventBlock_basic() string {
+	return `
+	resource "tsuru_event_block" "freeze" {
+		kind         = "app.deploy"
+		target_type  = "app"
+		target_value = "app01"
+		conditions = {
+			pool = "prod"
+		}
+		reason = "release freeze"
+	}
+`
+}
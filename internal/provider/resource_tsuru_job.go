@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
@@ -31,6 +29,19 @@ func resourceTsuruJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTsuruJobImport,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			provider := meta.(*tsuruProvider)
+			if err := setProviderDefaults(d, provider, "team_owner", "pool", "plan"); err != nil {
+				return err
+			}
+			if err := setTagsAll(d, provider); err != nil {
+				return err
+			}
+			if err := setMetadataAll(d, provider); err != nil {
+				return err
+			}
+			return validatePoolPlanTeamDiff(ctx, d, provider)
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -44,18 +55,21 @@ func resourceTsuruJob() *schema.Resource {
 			},
 			"plan": {
 				Type:        schema.TypeString,
-				Description: "Plan",
-				Required:    true,
+				Description: "Plan, defaults to the provider default_plan on creation",
+				Optional:    true,
+				Computed:    true,
 			},
 			"team_owner": {
 				Type:        schema.TypeString,
-				Description: "Job owner",
-				Required:    true,
+				Description: "Job owner, defaults to the provider default_team_owner on creation",
+				Optional:    true,
+				Computed:    true,
 			},
 			"pool": {
 				Type:        schema.TypeString,
-				Description: "The name of pool",
-				Required:    true,
+				Description: "The name of pool, defaults to the provider default_pool on creation",
+				Optional:    true,
+				Computed:    true,
 			},
 			"cluster": {
 				Type:        schema.TypeString,
@@ -89,6 +103,15 @@ func resourceTsuruJob() *schema.Resource {
 					},
 				},
 			},
+			"tags_all":     tagsAllSchema("job"),
+			"metadata_all": metadataAllSchema("job"),
+
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Prevent the job from being destroyed, it must be set to false and applied before destroying the job",
+				Optional:    true,
+				Default:     false,
+			},
 
 			"schedule": {
 				Type:        schema.TypeString,
@@ -134,6 +157,10 @@ func resourceTsuruJob() *schema.Resource {
 	}
 }
 
+// jobRequestAttributes are the attributes sent on job creation and update
+// the tsuru API may reject.
+var jobRequestAttributes = []string{"pool", "plan", "team_owner", "tags", "schedule", "concurrency_policy", "container.image"}
+
 func resourceTsuruJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
@@ -142,21 +169,12 @@ func resourceTsuruJobCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err = provider.TsuruClient.JobApi.CreateJob(ctx, job)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := provider.TsuruClient.JobApi.CreateJob(ctx, job)
+		return err
 	})
 	if err != nil {
-		return diag.Errorf("unable to create job %s: %v", job.Name, err)
+		return tsuruResourceDiagnostics(d, jobRequestAttributes, err, "unable to create job %s", job.Name)
 	}
 
 	d.SetId(job.Name)
@@ -166,39 +184,30 @@ func resourceTsuruJobCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 func resourceTsuruJobUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
+	if !d.HasChangesExcept("deletion_protection") {
+		return resourceTsuruJobRead(ctx, d, meta)
+	}
+
 	job, err := inputJobFromResourceData(ctx, d, provider)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	jobName := d.Id()
 
-	if d.HasChange("metadata") {
-		old, new := d.GetChange("metadata")
-		oldMetadata := metadataFromResourceData(old)
-		if oldMetadata == nil {
-			oldMetadata = &tsuru_client.Metadata{}
-		}
-		newMetadata := metadataFromResourceData(new)
-		if newMetadata == nil {
-			newMetadata = &tsuru_client.Metadata{}
-		}
+	unlock, err := provider.locks.lock(ctx, jobLock(jobName))
+	if err != nil {
+		return diag.Errorf("unable to lock job %s: %v", jobName, err)
+	}
+	defer unlock()
 
-		job.Metadata = tsuru_client.Metadata{
-			Annotations: markRemovedMetadataItemAsDeleted(oldMetadata.Annotations, newMetadata.Annotations),
-			Labels:      markRemovedMetadataItemAsDeleted(oldMetadata.Labels, newMetadata.Labels),
-		}
+	if d.HasChanges("metadata", "metadata_all") {
+		job.Metadata = metadataUpdate(provider, d)
 	}
 
-	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		resp, err := provider.TsuruClient.JobApi.UpdateJob(ctx, jobName, job)
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		resp, err := provider.TsuruClient.JobApi.UpdateJob(withIdempotentRequest(ctx), jobName, job)
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
+			return err
 		}
 
 		defer resp.Body.Close()
@@ -207,7 +216,7 @@ func resourceTsuruJobUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return nil
 	})
 	if err != nil {
-		return diag.Errorf("unable to update job %s: %v", jobName, err)
+		return tsuruResourceDiagnostics(d, jobRequestAttributes, err, "unable to update job %s", jobName)
 	}
 
 	return resourceTsuruJobRead(ctx, d, meta)
@@ -223,7 +232,7 @@ func resourceTsuruJobRead(ctx context.Context, d *schema.ResourceData, meta inte
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read job %s: %v", name, err)
+		return tsuruDiagnostics(err, "unable to read job %s", name)
 	}
 
 	d.Set("name", name)
@@ -249,7 +258,12 @@ func resourceTsuruJobRead(ctx context.Context, d *schema.ResourceData, meta inte
 		d.Set(key, value)
 	}
 
-	d.Set("metadata", flattenMetadata(job.Job.Metadata))
+	d.Set("metadata", flattenMetadata(withoutDefaultMetadata(provider, job.Job.Metadata, metadataFromResourceData(d.Get("metadata")))))
+	d.Set("metadata_all", flattenMetadata(job.Job.Metadata))
+	// tsuru does not return the tags of jobs, tags_all keeps the ones sent
+	if len(d.Get("tags_all").([]interface{})) == 0 {
+		d.Set("tags_all", mergeDefaultTags(provider, parseTags(d.Get("tags"))))
+	}
 
 	return nil
 }
@@ -258,21 +272,22 @@ func resourceTsuruJobDelete(ctx context.Context, d *schema.ResourceData, meta in
 	provider := meta.(*tsuruProvider)
 	name := d.Id()
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	if diags := checkDeletionProtection(provider, d, "job", name); diags != nil {
+		return diags
+	}
+
+	unlock, err := provider.locks.lock(ctx, jobLock(name))
+	if err != nil {
+		return diag.Errorf("unable to lock job %s: %v", name, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
 		_, err := provider.TsuruClient.JobApi.DeleteJob(ctx, name)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
+		return err
 	})
 	if err != nil {
-		return diag.Errorf("unable to delete job %s: %v", name, err)
+		return tsuruDiagnostics(err, "unable to delete job %s", name)
 	}
 
 	return nil
@@ -294,14 +309,7 @@ func resourceTsuruJobImport(ctx context.Context, d *schema.ResourceData, meta in
 
 func inputJobFromResourceData(ctx context.Context, d *schema.ResourceData, provider *tsuruProvider) (tsuru_client.InputJob, error) {
 	pool := d.Get("pool").(string)
-	if err := validPool(ctx, provider, pool); err != nil {
-		return tsuru_client.InputJob{}, err
-	}
-
 	plan := d.Get("plan").(string)
-	if err := validPlan(ctx, provider, plan); err != nil {
-		return tsuru_client.InputJob{}, err
-	}
 
 	tags := []string{}
 	for _, item := range d.Get("tags").([]interface{}) {
@@ -319,15 +327,12 @@ func inputJobFromResourceData(ctx context.Context, d *schema.ResourceData, provi
 		Pool:      pool,
 		Plan:      plan,
 		TeamOwner: d.Get("team_owner").(string),
-		Tags:      tags,
+		Tags:      mergeDefaultTags(provider, tags),
 		Container: container,
 	}
 
-	if m, ok := d.GetOk("metadata"); ok {
-		metadata := metadataFromResourceData(m)
-		if metadata != nil {
-			job.Metadata = *metadata
-		}
+	if metadata := mergeDefaultMetadata(provider, metadataFromResourceData(d.Get("metadata"))); metadata != nil {
+		job.Metadata = *metadata
 	}
 
 	if desc, ok := d.GetOk("description"); ok {
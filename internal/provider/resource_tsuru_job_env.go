@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
@@ -67,19 +65,20 @@ func resourceTsuruJobEnvironmentCreate(ctx context.Context, d *schema.ResourceDa
 	envs.Envs = append(envs.Envs, envsFromResource(d.Get("environment_variables"), false)...)
 	envs.Envs = append(envs.Envs, envsFromResource(d.Get("private_environment_variables"), true)...)
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		if len(envs.Envs) == 0 {
-			return resource.NonRetryableError(errors.Errorf("No environment variables to create"))
-		}
-		resp, err := provider.TsuruClient.JobApi.JobEnvSet(ctx, job, *envs)
+	unlock, err := provider.locks.lock(ctx, jobLock(job))
+	if err != nil {
+		return diag.Errorf("unable to lock job %s: %v", job, err)
+	}
+	defer unlock()
+
+	if len(envs.Envs) == 0 {
+		return diag.Errorf("No environment variables to create")
+	}
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		resp, err := provider.TsuruClient.JobApi.JobEnvSet(withIdempotentRequest(ctx), job, *envs)
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
+			return err
 		}
 
 		defer resp.Body.Close()
@@ -88,7 +87,7 @@ func resourceTsuruJobEnvironmentCreate(ctx context.Context, d *schema.ResourceDa
 		return nil
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to set envs of job %s", job)
 	}
 
 	d.SetId(job)
@@ -106,7 +105,7 @@ func resourceTsuruJobEnvironmentRead(ctx context.Context, d *schema.ResourceData
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read envs for job %s: %v", job, err)
+		return tsuruDiagnostics(err, "unable to read envs for job %s", job)
 	}
 
 	envs = filterUnmanagedTerraformEnvs(envs, provider.FullManagementEnvs)
@@ -144,21 +143,19 @@ func resourceTsuruJobEnvironmentUpdate(ctx context.Context, d *schema.ResourceDa
 	if len(envs.Envs) == 0 {
 		return diag.Errorf("No environment variables to update")
 	}
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		_, err := provider.TsuruClient.JobApi.JobEnvSet(ctx, job, *envs)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+
+	unlock, err := provider.locks.lock(ctx, jobLock(job))
+	if err != nil {
+		return diag.Errorf("unable to lock job %s: %v", job, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		_, err := provider.TsuruClient.JobApi.JobEnvSet(withIdempotentRequest(ctx), job, *envs)
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to update envs of job %s", job)
 	}
 
 	return resourceTsuruJobEnvironmentRead(ctx, d, meta)
@@ -169,25 +166,22 @@ func resourceTsuruJobEnvironmentDelete(ctx context.Context, d *schema.ResourceDa
 
 	job := d.Id()
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		_, err := provider.TsuruClient.JobApi.JobEnvSet(ctx, job, tsuru_client.EnvSetData{
+	unlock, err := provider.locks.lock(ctx, jobLock(job))
+	if err != nil {
+		return diag.Errorf("unable to lock job %s: %v", job, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		_, err := provider.TsuruClient.JobApi.JobEnvSet(withIdempotentRequest(ctx), job, tsuru_client.EnvSetData{
 			Envs:        []tsuru.Env{},
 			ManagedBy:   "terraform",
 			PruneUnused: true,
 		})
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
+		return err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to remove envs of job %s", job)
 	}
 
 	return nil
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -170,6 +172,80 @@ func TestAccResourceTsuruJobEnv(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruJobEnv_connectionClosed(t *testing.T) {
+	fakeServer := echo.New()
+
+	var mu sync.Mutex
+	envs := []tsuru.EnvVar{}
+	writes := 0
+
+	fakeServer.GET("/1.16/jobs/:job/env", func(c echo.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.JSON(http.StatusOK, envs)
+	})
+
+	fakeServer.POST("/1.13/jobs/:job/env", func(c echo.Context) error {
+		data := tsuru.EnvSetData{}
+		c.Bind(&data)
+
+		mu.Lock()
+		defer mu.Unlock()
+		writes++
+		if writes == 2 {
+			return closeConnection(c)
+		}
+
+		envs = []tsuru.EnvVar{}
+		for _, env := range data.Envs {
+			envs = append(envs, tsuru.EnvVar{Name: env.Name, Value: env.Value, Public: !env.Private, ManagedBy: "terraform"})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	provider := `
+	provider "tsuru" {
+		max_retries = 0
+	}
+`
+
+	resourceName := "tsuru_job_env.env"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccResourceTsuruJobEnv_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_variables.env1", "10"),
+				),
+			},
+			{
+				Config: provider + `
+	resource "tsuru_job_env" "env" {
+		job = "job01"
+		environment_variables = {
+			env1 = "15"
+		}
+		private_environment_variables = {
+			env2 = "20"
+		}
+	}
+`,
+				ExpectError: regexp.MustCompile(`(?s)unable to update envs of job job01.*unable to reach the tsuru API`),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruJobEnv_basic() string {
 	return `
 	resource "tsuru_job_env" "env" {
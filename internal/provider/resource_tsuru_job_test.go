@@ -5,12 +5,14 @@
 package provider
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -580,6 +582,122 @@ func TestAccResourceTsuruJobMetadataDeletion(t *testing.T) {
 	})
 }
 
+func TestAccResourceTsuruJob_defaultMetadata(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.GET("/1.0/pools", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Pool{{Name: "prod"}})
+	})
+
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []tsuru.Plan{{Name: "c1m1"}})
+	})
+
+	var job *tsuru.Job
+	fakeServer.POST("/1.13/jobs", func(c echo.Context) error {
+		input := tsuru.InputJob{}
+		c.Bind(&input)
+		assert.Equal(t, []string{"cost-center:42"}, input.Tags)
+		job = &tsuru.Job{
+			Name:      input.Name,
+			TeamOwner: input.TeamOwner,
+			Plan:      tsuru.Plan{Name: input.Plan},
+			Pool:      input.Pool,
+			Metadata:  input.Metadata,
+			Spec:      tsuru.JobSpec{Schedule: input.Schedule, Container: input.Container},
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"status": "success", "jobName": input.Name})
+	})
+
+	updates := []tsuru.InputJob{}
+	fakeServer.PUT("/1.13/jobs/:name", func(c echo.Context) error {
+		input := tsuru.InputJob{}
+		c.Bind(&input)
+		updates = append(updates, input)
+		job.Metadata.Labels = updateMetadataItems(job.Metadata.Labels, input.Metadata.Labels)
+		job.Metadata.Annotations = updateMetadataItems(job.Metadata.Annotations, input.Metadata.Annotations)
+		return c.JSON(http.StatusOK, nil)
+	})
+
+	fakeServer.GET("/1.13/jobs/:name", func(c echo.Context) error {
+		if job == nil {
+			return c.JSON(http.StatusNotFound, nil)
+		}
+		return c.JSON(http.StatusOK, tsuru.JobInfo{Job: *job})
+	})
+
+	fakeServer.DELETE("/1.13/jobs/:name", func(c echo.Context) error {
+		job = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_job.job"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruJob_defaultMetadata(`["cost-center:42"]`, `{"cost-center" = "42"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.0.labels.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.0", "cost-center:42"),
+				),
+			},
+			{
+				// only the defaults of the provider change
+				Config: testAccResourceTsuruJob_defaultMetadata(`["cost-center:43"]`, `{}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata_all.0.labels.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.0", "cost-center:43"),
+					func(s *terraform.State) error {
+						require.Len(t, updates, 1)
+						assert.Equal(t, []string{"cost-center:43"}, updates[0].Tags)
+						assert.Equal(t, []tsuru.MetadataItem{{Name: "label1", Value: "value1"}, {Name: "cost-center", Value: "42", Delete: true}}, updates[0].Metadata.Labels)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruJob_defaultMetadata(defaultTags, defaultLabels string) string {
+	return fmt.Sprintf(`
+	provider "tsuru" {
+		default_tags = %s
+		default_metadata {
+			labels = %s
+		}
+	}
+
+	resource "tsuru_job" "job" {
+		name       = "job01"
+		plan       = "c1m1"
+		team_owner = "my-team"
+		pool       = "prod"
+		schedule   = "* * * * *"
+		container {
+			image   = "tsuru/scratch:latest"
+			command = ["sleep", "600"]
+		}
+		metadata {
+			labels = {
+				"label1" = "value1"
+			}
+		}
+	}
+`, defaultTags, defaultLabels)
+}
+
 func testAccResourceTsuruJob_metadata() string {
 	return `
 	resource "tsuru_job" "job" {
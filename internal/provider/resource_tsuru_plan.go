@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -15,6 +16,7 @@ import (
 
 func resourceTsuruPlan() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Tsuru Plan, plans can't be updated in place because tsuru has no API to change them, so any change replaces the plan, use name_prefix with create_before_destroy to replace a plan used by apps",
 		CreateContext: resourceTsuruPlanCreate,
 		ReadContext:   resourceTsuruPlanRead,
 		DeleteContext: resourceTsuruPlanDelete,
@@ -28,19 +30,34 @@ func resourceTsuruPlan() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Description:  "Plan name",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Description: "Creates a plan with a unique name beginning with the prefix, tsuru plans can't be updated so changes replace them, use it with create_before_destroy to move apps to the new plan before the old one is removed",
+				Optional:    true,
+				ForceNew:    true,
 			},
 			"cpu": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Description:      "CPU limit, in units (1.5), millis (1500m) or percent (150%)",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateCPUQuantity,
+				DiffSuppressFunc: suppressEquivalentCPUQuantity,
 			},
 			"memory": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Description:      "Memory limit, in bytes or as a quantity (e.g. 512Mi)",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateMemoryQuantity,
+				DiffSuppressFunc: suppressEquivalentMemoryQuantity,
 			},
 			"cpu_burst": {
 				Type:     schema.TypeList,
@@ -77,32 +94,37 @@ func resourceTsuruPlan() *schema.Resource {
 func resourceTsuruPlanCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
-	plan := planResourceData(d)
+	if _, ok := d.GetOk("name"); !ok {
+		d.Set("name", id.PrefixedUniqueId(d.Get("name_prefix").(string)))
+	}
 
-	plan, _, err := provider.TsuruClient.PlanApi.PlanCreate(ctx, plan)
+	plan, err := planResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	_, _, err = provider.TsuruClient.PlanApi.PlanCreate(ctx, plan)
 
 	if err != nil {
-		return diag.Errorf("Could not create tsuru plan: %q, err: %s", plan.Name, err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru plan %q", name)
 	}
-	d.SetId(plan.Name)
+	d.SetId(name)
 
 	return resourceTsuruPlanRead(ctx, d, meta)
 }
 
-func planResourceData(d *schema.ResourceData) tsuru.Plan {
-	cpuString := d.Get("cpu").(string)
-	cpuFormat := cpuFormat(cpuString)
-	var cpuMilli int32
-	if cpuFormat == "unit" {
-		cpuMilli = cpuUnitToMilli(cpuString)
-	} else if cpuFormat == "percent" {
-		cpuMilli = cpuPercentToMilli(cpuString)
-	} else if cpuFormat == "milli" {
-		cpuMilli = cpuMilliInt32(cpuString)
+func planResourceData(d *schema.ResourceData) (tsuru.Plan, error) {
+	cpuMilli, err := parseCPUQuantity(d.Get("cpu").(string))
+	if err != nil {
+		return tsuru.Plan{}, err
 	}
 
 	memoryString := d.Get("memory").(string)
-	memoryBytes, _ := parseMemoryQuantity(memoryString)
+	memoryBytes, err := parseMemoryQuantity(memoryString)
+	if err != nil {
+		return tsuru.Plan{}, fmt.Errorf("invalid memory %q: %v", memoryString, err)
+	}
 
 	cpuBurst := tsuru.PlanCpuBurst{}
 	if m, ok := d.GetOk("cpu_burst"); ok {
@@ -115,7 +137,7 @@ func planResourceData(d *schema.ResourceData) tsuru.Plan {
 		Cpumilli: cpuMilli,
 		Default:  d.Get("default").(bool),
 		CpuBurst: cpuBurst,
-	}
+	}, nil
 }
 
 func resourceTsuruPlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -127,7 +149,7 @@ func resourceTsuruPlanRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	plans, _, err := provider.TsuruClient.PlanApi.PlanList(ctx)
 	if err != nil {
-		return diag.Errorf("Could not read tsuru plans err: %s", err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru plans")
 	}
 
 	for _, plan := range plans {
@@ -171,10 +193,12 @@ func resourceTsuruPlanRead(ctx context.Context, d *schema.ResourceData, meta int
 func resourceTsuruPlanDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*tsuruProvider)
 
-	plan := planResourceData(d)
-	_, err := provider.TsuruClient.PlanApi.DeletePlan(ctx, plan.Name)
+	_, err := provider.TsuruClient.PlanApi.DeletePlan(ctx, d.Id())
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru plan, err: %s", err.Error())
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "Could not delete tsuru plan")
 	}
 
 	return nil
@@ -222,6 +246,73 @@ func cpuMilliInt32(c string) int32 {
 	return int32(v)
 }
 
+// parseCPUQuantity returns the millis of a CPU in units, millis or percent.
+func parseCPUQuantity(c string) (int32, error) {
+	value := c
+	switch cpuFormat(c) {
+	case "percent", "milli":
+		value = c[0 : len(c)-1]
+	}
+
+	if _, err := strconv.ParseFloat(value, 32); err != nil {
+		return 0, fmt.Errorf("invalid cpu %q, use units (1.5), millis (1500m) or percent (150%%)", c)
+	}
+
+	switch cpuFormat(c) {
+	case "percent":
+		return cpuPercentToMilli(c), nil
+	case "milli":
+		return cpuMilliInt32(c), nil
+	}
+	return cpuUnitToMilli(c), nil
+}
+
+func validateCPUQuantity(i interface{}, k string) ([]string, []error) {
+	milli, err := parseCPUQuantity(i.(string))
+	if err != nil {
+		return nil, []error{err}
+	}
+	if milli <= 0 {
+		return nil, []error{fmt.Errorf("%s must be greater than zero", k)}
+	}
+	return nil, nil
+}
+
+func validateMemoryQuantity(i interface{}, k string) ([]string, []error) {
+	numBytes, err := parseMemoryQuantity(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid memory %q: %v", i.(string), err)}
+	}
+	if numBytes <= 0 {
+		return nil, []error{fmt.Errorf("%s must be greater than zero", k)}
+	}
+	return nil, nil
+}
+
+func suppressEquivalentCPUQuantity(k, old, new string, d *schema.ResourceData) bool {
+	oldMilli, err := parseCPUQuantity(old)
+	if err != nil {
+		return false
+	}
+	newMilli, err := parseCPUQuantity(new)
+	if err != nil {
+		return false
+	}
+	return oldMilli == newMilli
+}
+
+func suppressEquivalentMemoryQuantity(k, old, new string, d *schema.ResourceData) bool {
+	oldBytes, err := parseMemoryQuantity(old)
+	if err != nil {
+		return false
+	}
+	newBytes, err := parseMemoryQuantity(new)
+	if err != nil {
+		return false
+	}
+	return oldBytes == newBytes
+}
+
 func parseMemoryQuantity(m string) (numBytes int64, err error) {
 	if v, parseErr := strconv.Atoi(m); parseErr == nil {
 		return int64(v), nil
@@ -1,12 +1,16 @@
 package provider
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	echo "github.com/labstack/echo/v4"
 
 	"github.com/stretchr/testify/assert"
@@ -107,6 +111,149 @@ func TestAccTsuruPlan_basic(t *testing.T) {
 	})
 }
 
+func TestAccTsuruPlan_namePrefix(t *testing.T) {
+	fakeServer := echo.New()
+
+	plans := map[string]tsuru.Plan{}
+	fakeServer.POST("/1.0/plans", func(c echo.Context) error {
+		p := tsuru.Plan{}
+		err := c.Bind(&p)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(p.Name, "small-"))
+		plans[p.Name] = p
+		return c.JSON(200, p)
+	})
+	fakeServer.GET("/1.0/plans", func(c echo.Context) error {
+		result := []tsuru.Plan{}
+		for _, plan := range plans {
+			result = append(result, plan)
+		}
+		return c.JSON(http.StatusOK, result)
+	})
+	fakeServer.DELETE("/1.0/plans/:name", func(c echo.Context) error {
+		delete(plans, c.Param("name"))
+		return c.NoContent(http.StatusNoContent)
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	firstName := ""
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Empty(t, plans)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTsuruPlanConfig_namePrefix("1024Mi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("tsuru_plan.small", "name", regexp.MustCompile(`^small-`)),
+					resource.TestCheckResourceAttr("tsuru_plan.small", "memory", "1Gi"),
+					func(s *terraform.State) error {
+						firstName = s.RootModule().Resources["tsuru_plan.small"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// equivalent memory quantities don't replace the plan
+				Config:   testAccTsuruPlanConfig_namePrefix("1073741824"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccTsuruPlanConfig_namePrefix("2Gi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tsuru_plan.small", "memory", "2Gi"),
+					func(s *terraform.State) error {
+						assert.NotEqual(t, firstName, s.RootModule().Resources["tsuru_plan.small"].Primary.ID)
+						assert.Len(t, plans, 1)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccTsuruPlan_createError(t *testing.T) {
+	fakeServer := echo.New()
+	fakeServer.POST("/1.0/plans", func(c echo.Context) error {
+		return c.String(http.StatusConflict, "plan already exists")
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_plan" "plan" {
+	name   = "plan1"
+	cpu    = "1"
+	memory = "1Gi"
+}
+`,
+				ExpectError: regexp.MustCompile(`Could not create tsuru plan "plan1"`),
+			},
+		},
+	})
+}
+
+func TestAccTsuruPlan_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_plan" "plan" {
+	name   = "plan"
+	cpu    = "1"
+	memory = "1 GB"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid memory "1 GB"`),
+			},
+			{
+				Config: `
+resource "tsuru_plan" "plan" {
+	name   = "plan"
+	cpu    = "one"
+	memory = "1Gi"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid cpu "one"`),
+			},
+		},
+	})
+}
+
+func testAccTsuruPlanConfig_namePrefix(memory string) string {
+	return fmt.Sprintf(`
+resource "tsuru_plan" "small" {
+	name_prefix = "small-"
+	cpu         = "500m"
+	memory      = %q
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, memory)
+}
+
 func testAccTsuruPlanConfig_basic() string {
 	return `
 resource "tsuru_plan" "plan1" {
@@ -178,3 +325,20 @@ func TestCpuMilliInt32(t *testing.T) {
 	assert.Equal(t, int32(1000), cpuMilliInt32("1000m"))
 	assert.Equal(t, int32(200), cpuMilliInt32("200m"))
 }
+
+func TestParseCPUQuantity(t *testing.T) {
+	milli, err := parseCPUQuantity("1.5")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1500), milli)
+
+	milli, err = parseCPUQuantity("150%")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1500), milli)
+
+	milli, err = parseCPUQuantity("1500m")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1500), milli)
+
+	_, err = parseCPUQuantity("1.5 cores")
+	assert.EqualError(t, err, `invalid cpu "1.5 cores", use units (1.5), millis (1500m) or percent (150%)`)
+}
@@ -0,0 +1,336 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+var platformSources = []string{"dockerfile", "dockerfile_content", "image"}
+
+// unknownDockerfileChecksum is the checksum of platforms whose current image
+// was built outside of Terraform, it never matches a Dockerfile so the
+// platform is rebuilt on the next apply.
+const unknownDockerfileChecksum = "unknown"
+
+func resourceTsuruPlatform() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Platform",
+		CreateContext: resourceTsuruPlatformCreate,
+		ReadContext:   resourceTsuruPlatformRead,
+		UpdateContext: resourceTsuruPlatformUpdate,
+		DeleteContext: resourceTsuruPlatformDelete,
+		CustomizeDiff: resourceTsuruPlatformCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Platform name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dockerfile": {
+				Type:         schema.TypeString,
+				Description:  "Path to a local Dockerfile used to build the platform",
+				Optional:     true,
+				ExactlyOneOf: platformSources,
+			},
+			"dockerfile_content": {
+				Type:         schema.TypeString,
+				Description:  "Inline Dockerfile used to build the platform",
+				Optional:     true,
+				ExactlyOneOf: platformSources,
+			},
+			"image": {
+				Type:         schema.TypeString,
+				Description:  "Prebuilt image used as the platform, equivalent to a Dockerfile with a single FROM instruction",
+				Optional:     true,
+				ExactlyOneOf: platformSources,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the platform is available to apps (default = true)",
+				Optional:    true,
+				Default:     true,
+			},
+			"rollback_image": {
+				Type:        schema.TypeString,
+				Description: "Pin the platform to a previously built image, must be one of the `images` of this platform, removing it rebuilds the platform from its Dockerfile",
+				Optional:    true,
+			},
+			"dockerfile_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA256 checksum of the Dockerfile used on the last build, a change on it rebuilds the platform. Imported platforms adopt the checksum of the configured Dockerfile on the next apply without a rebuild, platforms rebuilt or rolled back outside of Terraform are rebuilt",
+				Computed:    true,
+			},
+			"current_image": {
+				Type:        schema.TypeString,
+				Description: "Image apps of this platform are built with, the newest of `images`. tsuru makes a rollback a new image built from the pinned one",
+				Computed:    true,
+			},
+			"images": {
+				Type:        schema.TypeList,
+				Description: "Images built for this platform, from oldest to newest",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTsuruPlatformCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, source := range platformSources {
+		if !d.NewValueKnown(source) {
+			return d.SetNewComputed("dockerfile_sha256")
+		}
+	}
+
+	dockerfile, err := platformDockerfile(d)
+	if err != nil {
+		return err
+	}
+
+	checksum := dockerfileChecksum(dockerfile)
+	if d.Get("dockerfile_sha256").(string) != checksum {
+		return d.SetNew("dockerfile_sha256", checksum)
+	}
+
+	return nil
+}
+
+func resourceTsuruPlatformCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	name := d.Get("name").(string)
+
+	dockerfile, err := platformDockerfile(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = tsuruPlatformRequest(ctx, provider, http.MethodPost, "/1.0/platforms", map[string]string{"name": name}, dockerfile)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to create platform %s", name)
+	}
+
+	d.SetId(name)
+	d.Set("dockerfile_sha256", dockerfileChecksum(dockerfile))
+
+	if !d.Get("enabled").(bool) {
+		err = tsuruPlatformRequest(ctx, provider, http.MethodPut, "/1.0/platforms/"+name, map[string]string{"disabled": "true"}, nil)
+		if err != nil {
+			return tsuruDiagnostics(err, "unable to disable platform %s", name)
+		}
+	}
+
+	if image, ok := d.GetOk("rollback_image"); ok {
+		if err = rollbackPlatform(ctx, provider, name, image.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return readPlatform(ctx, d, provider, false)
+}
+
+func resourceTsuruPlatformRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readPlatform(ctx, d, meta.(*tsuruProvider), true)
+}
+
+// readPlatform reads the platform into d, on refresh a current image other
+// than the one of the last apply means the platform was rebuilt or rolled
+// back outside of Terraform: the Dockerfile and the pin it runs are unknown.
+func readPlatform(ctx context.Context, d *schema.ResourceData, provider *tsuruProvider, refresh bool) diag.Diagnostics {
+	name := d.Id()
+
+	platform, _, err := provider.TsuruClient.PlatformApi.PlatformInfo(ctx, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read platform %s", name)
+	}
+
+	currentImage := ""
+	if len(platform.Images) > 0 {
+		currentImage = platform.Images[len(platform.Images)-1]
+	}
+	if previous := d.Get("current_image").(string); refresh && previous != "" && previous != currentImage {
+		log.Printf("[WARN] platform %s runs image %s instead of %s, it was changed outside of Terraform", name, currentImage, previous)
+		d.Set("dockerfile_sha256", unknownDockerfileChecksum)
+		d.Set("rollback_image", "")
+	}
+
+	d.Set("name", name)
+	d.Set("enabled", !platform.Platform.Disabled)
+	d.Set("images", platform.Images)
+	d.Set("current_image", currentImage)
+
+	return nil
+}
+
+func resourceTsuruPlatformUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	name := d.Id()
+
+	rebuilt := false
+	if d.HasChange("dockerfile_sha256") {
+		dockerfile, err := platformDockerfile(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// imported platforms have no checksum, the Dockerfile they were built
+		// with is unknown so the configured one is adopted without a rebuild
+		if old, _ := d.GetChange("dockerfile_sha256"); old.(string) != "" {
+			err = tsuruPlatformRequest(ctx, provider, http.MethodPut, "/1.0/platforms/"+name, nil, dockerfile)
+			if err != nil {
+				return tsuruDiagnostics(err, "unable to update platform %s", name)
+			}
+			rebuilt = true
+		}
+		d.Set("dockerfile_sha256", dockerfileChecksum(dockerfile))
+	}
+
+	if d.HasChange("enabled") {
+		disabled := strconv.FormatBool(!d.Get("enabled").(bool))
+		err := tsuruPlatformRequest(ctx, provider, http.MethodPut, "/1.0/platforms/"+name, map[string]string{"disabled": disabled}, nil)
+		if err != nil {
+			return tsuruDiagnostics(err, "unable to update platform %s", name)
+		}
+	}
+
+	if d.HasChange("rollback_image") {
+		image := d.Get("rollback_image").(string)
+		if image != "" {
+			if err := rollbackPlatform(ctx, provider, name, image); err != nil {
+				return diag.FromErr(err)
+			}
+		} else if !rebuilt {
+			// tsuru makes a rollback the newest image, removing the pin
+			// rebuilds the platform from its Dockerfile
+			dockerfile, err := platformDockerfile(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = tsuruPlatformRequest(ctx, provider, http.MethodPut, "/1.0/platforms/"+name, nil, dockerfile)
+			if err != nil {
+				return tsuruDiagnostics(err, "unable to update platform %s", name)
+			}
+		}
+	}
+
+	return readPlatform(ctx, d, provider, false)
+}
+
+func resourceTsuruPlatformDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	name := d.Id()
+
+	_, err := provider.TsuruClient.PlatformApi.PlatformDelete(ctx, name)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to delete platform %s", name)
+	}
+
+	return nil
+}
+
+func rollbackPlatform(ctx context.Context, provider *tsuruProvider, name, image string) error {
+	resp, err := provider.TsuruClient.PlatformApi.PlatformRollback(ctx, name, image)
+	if err != nil {
+		return errors.Errorf("unable to rollback platform %s to %s: %v", name, image, err)
+	}
+
+	defer resp.Body.Close()
+	if err = readTsuruStream(resp.Body); err != nil {
+		return errors.Errorf("unable to rollback platform %s to %s: %v", name, image, err)
+	}
+
+	return nil
+}
+
+type platformSourceGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+func platformDockerfile(d platformSourceGetter) ([]byte, error) {
+	if path, ok := d.GetOk("dockerfile"); ok {
+		content, err := os.ReadFile(path.(string))
+		if err != nil {
+			return nil, errors.Errorf("unable to read dockerfile: %v", err)
+		}
+		return content, nil
+	}
+
+	if content, ok := d.GetOk("dockerfile_content"); ok {
+		return []byte(content.(string)), nil
+	}
+
+	if image, ok := d.GetOk("image"); ok {
+		return []byte("FROM " + image.(string)), nil
+	}
+
+	return nil, errors.New("one of dockerfile, dockerfile_content or image must be specified")
+}
+
+func dockerfileChecksum(dockerfile []byte) string {
+	sum := sha256.Sum256(dockerfile)
+	return hex.EncodeToString(sum[:])
+}
+
+// tsuruPlatformRequest sends the multipart form expected by the platform
+// endpoints, the generated client has no way to send both the dockerfile and
+// the other platform args.
+func tsuruPlatformRequest(ctx context.Context, provider *tsuruProvider, method, path string, args map[string]string, dockerfile []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for key, value := range args {
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+
+	if dockerfile != nil {
+		part, err := writer.CreateFormFile("dockerfile_content", "Dockerfile")
+		if err != nil {
+			return err
+		}
+		if _, err = part.Write(dockerfile); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	resp, err := tsuruRequest(ctx, provider, method, path, writer.FormDataContentType(), &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return readTsuruStream(resp.Body)
+}
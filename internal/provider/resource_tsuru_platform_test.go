@@ -0,0 +1,272 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruPlatform(t *testing.T) {
+	fakeServer := echo.New()
+
+	platform := tsuru.PlatformInfo{}
+	builds := []string{}
+	rollbacks := []string{}
+
+	readDockerfile := func(c echo.Context) string {
+		file, err := c.FormFile("dockerfile_content")
+		if err != nil {
+			return ""
+		}
+		f, err := file.Open()
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	fakeServer.POST("/1.0/platforms", func(c echo.Context) error {
+		name := c.FormValue("name")
+		assert.Equal(t, "python", name)
+
+		builds = append(builds, readDockerfile(c))
+		platform = tsuru.PlatformInfo{
+			Platform: tsuru.Platform{Name: name},
+			Images:   []string{fmt.Sprintf("tsuru/python:v%d", len(builds))},
+		}
+		return c.String(http.StatusOK, `{"Message":"building platform\n"}`+"\n")
+	})
+
+	fakeServer.PUT("/1.0/platforms/:name", func(c echo.Context) error {
+		assert.Equal(t, "python", c.Param("name"))
+
+		if disabled := c.FormValue("disabled"); disabled != "" {
+			platform.Platform.Disabled = disabled == "true"
+		}
+		if dockerfile := readDockerfile(c); dockerfile != "" {
+			builds = append(builds, dockerfile)
+			platform.Images = append(platform.Images, fmt.Sprintf("tsuru/python:v%d", len(builds)))
+		}
+		return c.String(http.StatusOK, `{"Message":"ok\n"}`+"\n")
+	})
+
+	fakeServer.POST("/1.6/platforms/:name/rollback", func(c echo.Context) error {
+		// tsuru builds a new image from the one rolled back to
+		rollbacks = append(rollbacks, c.QueryParam("image"))
+		builds = append(builds, "FROM "+c.QueryParam("image"))
+		platform.Images = append(platform.Images, fmt.Sprintf("tsuru/python:v%d", len(builds)))
+		return c.String(http.StatusOK, `{"Message":"rollback done\n"}`+"\n")
+	})
+
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		if platform.Platform.Name == "" {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.JSON(http.StatusOK, platform)
+	})
+
+	fakeServer.DELETE("/1.0/platforms/:name", func(c echo.Context) error {
+		platform = tsuru.PlatformInfo{}
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_platform.python"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruPlatform_image(server.URL, "tsuru/python:3.12"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "python"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "images.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "images.0", "tsuru/python:v1"),
+					resource.TestCheckResourceAttr(resourceName, "dockerfile_sha256", dockerfileChecksum([]byte("FROM tsuru/python:3.12"))),
+					func(s *terraform.State) error {
+						assert.Equal(t, []string{"FROM tsuru/python:3.12"}, builds)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTsuruPlatform_content(server.URL, "FROM tsuru/python:3.13\nRUN echo ok", false, "tsuru/python:v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rollback_image", "tsuru/python:v1"),
+					resource.TestCheckResourceAttr(resourceName, "images.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "images.1", "tsuru/python:v2"),
+					resource.TestCheckResourceAttr(resourceName, "current_image", "tsuru/python:v3"),
+					func(s *terraform.State) error {
+						assert.Equal(t, []string{"FROM tsuru/python:3.12", "FROM tsuru/python:3.13\nRUN echo ok", "FROM tsuru/python:v1"}, builds)
+						assert.Equal(t, []string{"tsuru/python:v1"}, rollbacks)
+						return nil
+					},
+				),
+			},
+			{
+				// rebuilt outside of Terraform, the pin is lost
+				PreConfig: func() {
+					builds = append(builds, "FROM someone/else")
+					platform.Images = append(platform.Images, fmt.Sprintf("tsuru/python:v%d", len(builds)))
+				},
+				Config: testAccResourceTsuruPlatform_content(server.URL, "FROM tsuru/python:3.13\nRUN echo ok", false, "tsuru/python:v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rollback_image", "tsuru/python:v1"),
+					resource.TestCheckResourceAttr(resourceName, "dockerfile_sha256", dockerfileChecksum([]byte("FROM tsuru/python:3.13\nRUN echo ok"))),
+					resource.TestCheckResourceAttr(resourceName, "current_image", "tsuru/python:v6"),
+					func(s *terraform.State) error {
+						assert.Equal(t, "FROM tsuru/python:3.13\nRUN echo ok", builds[4])
+						assert.Equal(t, []string{"tsuru/python:v1", "tsuru/python:v1"}, rollbacks)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTsuruPlatform_content(server.URL, "FROM tsuru/python:3.13\nRUN echo ok", false, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rollback_image", ""),
+					resource.TestCheckResourceAttr(resourceName, "current_image", "tsuru/python:v7"),
+					func(s *terraform.State) error {
+						assert.Equal(t, "FROM tsuru/python:3.13\nRUN echo ok", builds[6])
+						assert.Len(t, rollbacks, 2)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruPlatform_import(t *testing.T) {
+	fakeServer := echo.New()
+
+	builds := 0
+	platform := tsuru.PlatformInfo{
+		Platform: tsuru.Platform{Name: "python"},
+		Images:   []string{"tsuru/python:v1"},
+	}
+
+	fakeServer.GET("/1.6/platforms/:name", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, platform)
+	})
+
+	fakeServer.PUT("/1.0/platforms/:name", func(c echo.Context) error {
+		builds++
+		return c.String(http.StatusOK, `{"Message":"ok\n"}`+"\n")
+	})
+
+	fakeServer.DELETE("/1.0/platforms/:name", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_platform.python"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccResourceTsuruPlatform_image(server.URL, "tsuru/python:3.12"),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateId:      "python",
+				ImportStatePersist: true,
+			},
+			{
+				Config: testAccResourceTsuruPlatform_image(server.URL, "tsuru/python:3.12"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "dockerfile_sha256", dockerfileChecksum([]byte("FROM tsuru/python:3.12"))),
+					func(s *terraform.State) error {
+						assert.Equal(t, 0, builds)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTsuruPlatformBuildError(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.POST("/1.0/platforms", func(c echo.Context) error {
+		return c.String(http.StatusOK, `{"Message":"step 1/2\n"}`+"\n"+`{"Message":"","Error":"unable to pull image"}`+"\n")
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTsuruPlatform_image(server.URL, "tsuru/python:3.12"),
+				ExpectError: regexp.MustCompile(`(?s)unable to create platform python.*unable to pull image`),
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruPlatform_image(serverURL, image string) string {
+	return fmt.Sprintf(`
+provider "tsuru" {
+	host = "%s"
+}
+
+resource "tsuru_platform" "python" {
+	name  = "python"
+	image = "%s"
+}
+`, serverURL, image)
+}
+
+func testAccResourceTsuruPlatform_content(serverURL, content string, enabled bool, rollbackImage string) string {
+	return fmt.Sprintf(`
+provider "tsuru" {
+	host = "%s"
+}
+
+resource "tsuru_platform" "python" {
+	name               = "python"
+	dockerfile_content = %q
+	enabled            = %t
+	rollback_image     = "%s"
+}
+`, serverURL, content, enabled, rollbackImage)
+}
@@ -79,7 +79,7 @@ func resourceTsuruPoolCreate(ctx context.Context, d *schema.ResourceData, meta i
 	})
 
 	if err != nil {
-		return diag.Errorf("Could not create tsuru pool: %q, err: %s", name, err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru pool %q", name)
 	}
 	d.SetId(name)
 
@@ -95,7 +95,7 @@ func resourceTsuruPoolRead(ctx context.Context, d *schema.ResourceData, meta int
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("Could not read tsuru pool: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru pool %q", d.Id())
 	}
 	d.Set("name", pool.Name)
 	d.Set("tsuru_provisioner", pool.Provisioner)
@@ -124,7 +124,7 @@ func resourceTsuruPoolUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		Labels:  labels,
 	})
 	if err != nil {
-		return diag.Errorf("Could not update tsuru pool: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not update tsuru pool %q", d.Id())
 	}
 
 	return resourceTsuruPoolRead(ctx, d, meta)
@@ -135,7 +135,7 @@ func resourceTsuruPoolDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	_, err := provider.TsuruClient.PoolApi.PoolDelete(ctx, d.Id())
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru pool: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not delete tsuru pool %q", d.Id())
 	}
 
 	return nil
@@ -76,13 +76,13 @@ func resourceTsuruPoolConstraintSet(ctx context.Context, d *schema.ResourceData,
 		Blacklist: d.Get("blacklist").(bool),
 	}
 
-	err := tsuruRetry(ctx, d, func() error {
-		_, internalErr := provider.TsuruClient.PoolApi.ConstraintSet(ctx, constraint)
+	err := tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
+		_, internalErr := provider.TsuruClient.PoolApi.ConstraintSet(withIdempotentRequest(ctx), constraint)
 		return internalErr
 	})
 
 	if err != nil {
-		return diag.Errorf("Could not set tsuru pool pool constraint: %q, err: %s", id, err.Error())
+		return tsuruDiagnostics(err, "Could not set tsuru pool pool constraint %q", id)
 	}
 	d.SetId(id)
 
@@ -100,7 +100,7 @@ func resourceTsuruPoolConstraintRead(ctx context.Context, d *schema.ResourceData
 	constraints, _, err := provider.TsuruClient.PoolApi.ConstraintList(ctx)
 
 	if err != nil {
-		return diag.Errorf("Could not list tsuru pool pool constraints, err: %s", err.Error())
+		return tsuruDiagnostics(err, "Could not list tsuru pool pool constraints")
 	}
 
 	for _, constraint := range constraints {
@@ -130,8 +130,8 @@ func resourceTsuruPoolConstraintDelete(ctx context.Context, d *schema.ResourceDa
 
 	id := d.Get("pool_expr").(string) + "/" + d.Get("field").(string)
 
-	err := tsuruRetry(ctx, d, func() error {
-		_, internalErr := provider.TsuruClient.PoolApi.ConstraintSet(ctx, tsuru.PoolConstraintSet{
+	err := tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
+		_, internalErr := provider.TsuruClient.PoolApi.ConstraintSet(withIdempotentRequest(ctx), tsuru.PoolConstraintSet{
 			PoolExpr: d.Get("pool_expr").(string),
 			Field:    d.Get("field").(string),
 			Values:   []string{},
@@ -141,7 +141,7 @@ func resourceTsuruPoolConstraintDelete(ctx context.Context, d *schema.ResourceDa
 	})
 
 	if err != nil {
-		return diag.Errorf("Could not set tsuru pool empty pool constraints: %q, err: %s", id, err.Error())
+		return tsuruDiagnostics(err, "Could not set tsuru pool empty pool constraints %q", id)
 	}
 
 	return nil
@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"time"
 
 	yaml "github.com/ghodss/yaml"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -45,16 +48,28 @@ func resourceTsuruRouter() *schema.Resource {
 				Description: "List of readiness gates associated with this router",
 			},
 			"config": {
-				Type:        schema.TypeString,
-				Description: "Configuration for router in YAML format",
-				Optional:    true,
+				Type:             schema.TypeString,
+				Description:      "Configuration for router in YAML format, formatting, comments and key order are ignored on diffs",
+				Optional:         true,
+				ConflictsWith:    []string{"config_map"},
+				ValidateFunc:     validateRouterConfig,
+				DiffSuppressFunc: suppressEquivalentRouterConfig,
+			},
+			"config_map": {
+				Type:             schema.TypeMap,
+				Description:      "Configuration for router as a map, values that are valid JSON (numbers, booleans and jsonencode() objects) keep their types, any other value is a string, use jsonencode() to send a string like \"true\" or \"30\"",
+				Optional:         true,
+				ConflictsWith:    []string{"config"},
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateRouterConfigMap,
+				DiffSuppressFunc: suppressEquivalentRouterConfigValue,
 			},
 		},
 	}
 }
 
 func routerFromResourceData(d *schema.ResourceData) (tsuru.DynamicRouter, diag.Diagnostics) {
-	config, err := parseRouterConfig(d.Get("config"))
+	config, err := routerConfigFromResourceData(d)
 	if err != nil {
 		return tsuru.DynamicRouter{}, diag.Errorf("Could not decode config, err : %s", err.Error())
 	}
@@ -82,7 +97,7 @@ func resourceTsuruRouterCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	_, err := provider.TsuruClient.RouterApi.RouterCreate(ctx, router)
 	if err != nil {
-		return diag.Errorf("Could not create tsuru router, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru router")
 	}
 
 	d.SetId(router.Name)
@@ -99,7 +114,7 @@ func resourceTsuruRouterRead(ctx context.Context, d *schema.ResourceData, meta i
 	router, _, err := provider.TsuruClient.RouterApi.RouterList(ctx)
 
 	if err != nil {
-		return diag.Errorf("Could not read tsuru router, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru router")
 	}
 
 	for _, router := range router {
@@ -110,12 +125,21 @@ func resourceTsuruRouterRead(ctx context.Context, d *schema.ResourceData, meta i
 		d.Set("type", router.Type)
 		d.Set("readiness_gates", router.ReadinessGates)
 
+		if len(d.Get("config_map").(map[string]interface{})) > 0 {
+			configMap, err := flattenRouterConfigMap(router.Config)
+			if err != nil {
+				return diag.Errorf("Could not encode config, err : %s", err.Error())
+			}
+			d.Set("config_map", configMap)
+			return nil
+		}
+
 		config, err := parseRouterConfig(d.Get("config"))
 		if err != nil {
 			return diag.Errorf("Could not decode config, err : %s", err.Error())
 		}
 
-		if !reflect.DeepEqual(config, router.Config) {
+		if !reflect.DeepEqual(config, normalizeRouterConfig(router.Config)) {
 			b, err := yaml.Marshal(router.Config)
 			if err != nil {
 				return diag.Errorf("Could not encode config, err : %s", err.Error())
@@ -139,7 +163,7 @@ func resourceTsuruRouterUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	_, err := provider.TsuruClient.RouterApi.RouterUpdate(ctx, d.Id(), router)
 	if err != nil {
-		return diag.Errorf("Could not update tsuru router: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not update tsuru router %q", d.Id())
 	}
 
 	return resourceTsuruRouterRead(ctx, d, meta)
@@ -149,7 +173,7 @@ func resourceTsuruRouterDelete(ctx context.Context, d *schema.ResourceData, meta
 	provider := meta.(*tsuruProvider)
 	_, err := provider.TsuruClient.RouterApi.RouterDelete(ctx, d.Id())
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru router, err: %s", err.Error())
+		return tsuruDiagnostics(err, "Could not delete tsuru router")
 	}
 
 	return nil
@@ -166,3 +190,117 @@ func parseRouterConfig(data interface{}) (map[string]interface{}, error) {
 
 	return config, nil
 }
+
+func routerConfigFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	configMap := d.Get("config_map").(map[string]interface{})
+	if len(configMap) == 0 {
+		return parseRouterConfig(d.Get("config"))
+	}
+
+	config := map[string]interface{}{}
+	for key, value := range configMap {
+		decoded, err := decodeRouterConfigValue(value.(string))
+		if err != nil {
+			return nil, errors.Errorf("invalid value of %s: %v", key, err)
+		}
+		config[key] = decoded
+	}
+	return config, nil
+}
+
+// decodeRouterConfigValue decodes a value of config_map as JSON, anything
+// that is not JSON is kept as a string, so YAML 1.1 values like yes or 0123
+// are not turned into booleans or numbers. Values that look like JSON
+// objects or lists must be valid.
+func decodeRouterConfigValue(value string) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			return nil, err
+		}
+		return value, nil
+	}
+	return decoded, nil
+}
+
+// flattenRouterConfigMap encodes the values of config back to config_map,
+// strings are kept as is unless they would be decoded as something else,
+// everything else is encoded as JSON.
+func flattenRouterConfigMap(config map[string]interface{}) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for key, value := range config {
+		if str, ok := value.(string); ok {
+			if decoded, err := decodeRouterConfigValue(str); err == nil && decoded == str {
+				result[key] = str
+				continue
+			}
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = string(b)
+	}
+	return result, nil
+}
+
+// normalizeRouterConfig round trips config through JSON, so it has the same
+// types as a config decoded from YAML.
+func normalizeRouterConfig(config map[string]interface{}) map[string]interface{} {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return config
+	}
+	result := map[string]interface{}{}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return config
+	}
+	return result
+}
+
+func validateRouterConfig(i interface{}, k string) ([]string, []error) {
+	if _, err := parseRouterConfig(i); err != nil {
+		return nil, []error{errors.Errorf("%s is not a valid YAML map: %v", k, err)}
+	}
+	return nil, nil
+}
+
+func validateRouterConfigMap(i interface{}, k string) ([]string, []error) {
+	var errs []error
+	for key, value := range i.(map[string]interface{}) {
+		if _, err := decodeRouterConfigValue(value.(string)); err != nil {
+			errs = append(errs, errors.Errorf("%s.%s is not valid JSON: %v", k, key, err))
+		}
+	}
+	return nil, errs
+}
+
+func suppressEquivalentRouterConfig(k, old, new string, d *schema.ResourceData) bool {
+	oldConfig, err := parseRouterConfig(old)
+	if err != nil {
+		return false
+	}
+	newConfig, err := parseRouterConfig(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldConfig, newConfig)
+}
+
+func suppressEquivalentRouterConfigValue(k, old, new string, d *schema.ResourceData) bool {
+	// the map size, e.g. config_map.%, is compared as is
+	if strings.HasSuffix(k, ".%") {
+		return old == new
+	}
+
+	oldValue, err := decodeRouterConfigValue(old)
+	if err != nil {
+		return false
+	}
+	newValue, err := decodeRouterConfigValue(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -74,10 +75,149 @@ func TestAccTsuruRouter_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "type", "router"),
 				),
 			},
+			{
+				Config: `
+resource "tsuru_router"  "test_router"   {
+	name = "test_router"
+	type = "router"
+	readiness_gates = ["gate1", "gate2"]
+
+	# same config, reordered and with comments
+	config = <<-EOT
+	headers: {"x-my-header": "test"}
+	# router API
+	url: testing
+	EOT
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccTsuruRouter_invalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "tsuru_router"  "test_router"   {
+	name = "test_router"
+	type = "router"
+
+	config = <<-EOT
+	url: [testing
+	EOT
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`config is not a valid YAML map`),
+			},
 		},
 	})
 }
 
+func TestAccTsuruRouter_configMap(t *testing.T) {
+	fakeServer := echo.New()
+
+	var stored *tsuru.DynamicRouter
+	fakeServer.POST("/1.8/routers", func(c echo.Context) error {
+		p := &tsuru.DynamicRouter{}
+		err := c.Bind(&p)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"url":     "testing",
+			"timeout": float64(30),
+			"debug":   true,
+			"tls":     "on",
+			"port":    "0123",
+			"label":   "true",
+			"headers": map[string]interface{}{
+				"x-my-header": "test",
+			},
+		}, p.Config)
+		stored = p
+		return nil
+	})
+	fakeServer.PUT("/1.8/routers/:name", func(c echo.Context) error {
+		p := &tsuru.DynamicRouter{}
+		err := c.Bind(&p)
+		require.NoError(t, err)
+		assert.Equal(t, float64(60), p.Config["timeout"])
+		stored = p
+		return nil
+	})
+	fakeServer.GET("/1.3/routers", func(c echo.Context) error {
+		if stored == nil {
+			return c.JSON(http.StatusOK, []*tsuru.DynamicRouter{})
+		}
+		return c.JSON(http.StatusOK, []*tsuru.DynamicRouter{stored})
+	})
+	fakeServer.DELETE("/1.8/routers/:name", func(c echo.Context) error {
+		stored = nil
+		return c.NoContent(http.StatusNoContent)
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_router.test_router"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTsuruRouterConfig_configMap(30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "config_map.url", "testing"),
+					resource.TestCheckResourceAttr(resourceName, "config_map.timeout", "30"),
+					resource.TestCheckResourceAttr(resourceName, "config_map.debug", "true"),
+					resource.TestCheckResourceAttr(resourceName, "config_map.tls", "on"),
+					resource.TestCheckResourceAttr(resourceName, "config_map.port", "0123"),
+					resource.TestCheckResourceAttr(resourceName, "config_map.label", `"true"`),
+				),
+			},
+			{
+				Config: testAccTsuruRouterConfig_configMap(60),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config_map.timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTsuruRouterConfig_configMap(timeout int) string {
+	return fmt.Sprintf(`
+locals {
+	defaults = {
+		url   = "testing"
+		debug = true
+		# not JSON, kept as strings
+		tls   = "on"
+		port  = "0123"
+		label = jsonencode("true")
+	}
+}
+
+resource "tsuru_router" "test_router" {
+	name = "test_router"
+	type = "router"
+
+	config_map = merge(local.defaults, {
+		timeout = %d
+		headers = jsonencode({"x-my-header" = "test"})
+	})
+}
+`, timeout)
+}
+
 func testAccTsuruRouterConfig_basic(fakeServer, name string) string {
 	return fmt.Sprintf(`
 resource "tsuru_router"  "test_router"   {
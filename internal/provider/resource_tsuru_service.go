@@ -118,7 +118,7 @@ func resourceTsuruServiceCreate(ctx context.Context, d *schema.ResourceData, met
 
 	_, err := provider.TsuruClient.ServiceApi.ServiceCreate(ctx, opts)
 	if err != nil {
-		return diag.Errorf("Could not create tsuru service %q, err: %s", name, err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru service %q", name)
 	}
 
 	d.SetId(name)
@@ -133,7 +133,7 @@ func resourceTsuruServiceRead(ctx context.Context, d *schema.ResourceData, meta
 
 	_, resp, err := provider.TsuruClient.ServiceApi.ServiceInfo(ctx, name)
 	if err != nil {
-		return diag.Errorf("Could not get tsuru service %q, err: %s", name, err.Error())
+		return tsuruDiagnostics(err, "Could not get tsuru service %q", name)
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
@@ -187,7 +187,7 @@ func resourceTsuruServiceUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	_, err := provider.TsuruClient.ServiceApi.ServiceUpdate(ctx, name, opts)
 	if err != nil {
-		return diag.Errorf("Could not update tsuru service %q, err: %s", name, err.Error())
+		return tsuruDiagnostics(err, "Could not update tsuru service %q", name)
 	}
 
 	return resourceTsuruServiceRead(ctx, d, meta)
@@ -212,7 +212,7 @@ func resourceTsuruServiceDelete(ctx context.Context, d *schema.ResourceData, met
 
 	_, err := provider.TsuruClient.ServiceApi.ServiceDelete(ctx, name)
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru service %q, err: %s", name, err.Error())
+		return tsuruDiagnostics(err, "Could not delete tsuru service %q", name)
 	}
 
 	return nil
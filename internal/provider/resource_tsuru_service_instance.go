@@ -13,8 +13,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -32,6 +32,15 @@ func resourceTsuruServiceInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// instances may have no pool, the provider default_pool is
+			// only used by apps, jobs and volumes
+			provider := meta.(*tsuruProvider)
+			if err := setProviderDefaults(d, provider, "owner"); err != nil {
+				return err
+			}
+			return setTagsAll(d, provider)
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -53,8 +62,9 @@ func resourceTsuruServiceInstance() *schema.Resource {
 			},
 			"owner": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Team owner of this instance",
+				Optional:    true,
+				Computed:    true,
+				Description: "Team owner of this instance, defaults to the provider default_team_owner on creation",
 			},
 			"pool": {
 				Type:        schema.TypeString,
@@ -75,6 +85,7 @@ func resourceTsuruServiceInstance() *schema.Resource {
 				Optional:    true,
 				Description: "Custom tags for instance",
 			},
+			"tags_all": tagsAllSchema("instance"),
 			"parameters": {
 				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -87,6 +98,12 @@ func resourceTsuruServiceInstance() *schema.Resource {
 				Optional:    true,
 				Description: "Unbind service instance from apps on delete (default = true)",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Prevent the service instance from being destroyed, it must be set to false and applied before destroying the service instance",
+				Optional:    true,
+				Default:     false,
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -122,9 +139,7 @@ func resourceTsuruServiceInstanceCreate(ctx context.Context, d *schema.ResourceD
 		instance.Description = description.(string)
 	}
 
-	if tags, ok := d.GetOk("tags"); ok {
-		instance.Tags = parseTags(tags)
-	}
+	instance.Tags = mergeDefaultTags(provider, parseTags(d.Get("tags")))
 
 	if parameters, ok := d.GetOk("parameters"); ok {
 		instance.Parameters = parseParameters(parameters)
@@ -132,7 +147,7 @@ func resourceTsuruServiceInstanceCreate(ctx context.Context, d *schema.ResourceD
 
 	_, err := provider.TsuruClient.ServiceApi.InstanceCreate(ctx, serviceName, instance)
 	if err != nil {
-		return diag.Errorf("Could not create tsuru service instance, err : %s", err.Error())
+		return tsuruDiagnostics(err, "Could not create tsuru service instance")
 	}
 
 	d.SetId(createID([]string{serviceName, name}))
@@ -140,11 +155,9 @@ func resourceTsuruServiceInstanceCreate(ctx context.Context, d *schema.ResourceD
 	if waitForStatus, ok := d.GetOk("wait_for_up_status"); ok {
 		if waitForStatus.(bool) {
 			log.Printf("[INFO] Waiting for service_instance %s/%s to reach up status", serviceName, name)
-			err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-				waitForServiceInstanceStatusUpFunc(ctx, provider, serviceName, name),
-			)
+			err := waitForServiceInstanceStatusUp(ctx, provider, serviceName, name, d.Timeout(schema.TimeoutCreate))
 			if err != nil {
-				return diag.FromErr(err)
+				return tsuruDiagnostics(err, "service instance %s/%s did not reach up status", serviceName, name)
 			}
 		}
 	}
@@ -168,7 +181,7 @@ func resourceTsuruServiceInstanceRead(ctx context.Context, d *schema.ResourceDat
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("Could not read tsuru service (%s) instance (%s), err : %s", serviceName, name, err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru service (%s) instance (%s)", serviceName, name)
 	}
 
 	d.Set("name", name)
@@ -181,8 +194,14 @@ func resourceTsuruServiceInstanceRead(ctx context.Context, d *schema.ResourceDat
 		d.Set("description", instance.Description)
 	}
 
+	if tags := withoutDefaultTags(provider, instance.Tags, parseTags(d.Get("tags"))); len(tags) > 0 {
+		d.Set("tags", tags)
+	}
+	// like tags, no tags read keeps the ones sent
 	if len(instance.Tags) > 0 {
-		d.Set("tags", instance.Tags)
+		d.Set("tags_all", instance.Tags)
+	} else {
+		d.Set("tags_all", mergeDefaultTags(provider, parseTags(d.Get("tags"))))
 	}
 
 	if len(instance.Parameters) > 0 {
@@ -191,7 +210,7 @@ func resourceTsuruServiceInstanceRead(ctx context.Context, d *schema.ResourceDat
 
 	status, err := serviceInstanceStatus(ctx, provider, serviceName, name)
 	if err != nil {
-		return diag.Errorf("Could not read tsuru service (%s) instance (%s) status, err : %s", serviceName, name, err.Error())
+		return tsuruDiagnostics(err, "Could not read tsuru service (%s) instance (%s) status", serviceName, name)
 	}
 
 	d.Set("status", status)
@@ -200,6 +219,10 @@ func resourceTsuruServiceInstanceRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceTsuruServiceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChangesExcept("deletion_protection") {
+		return resourceTsuruServiceInstanceRead(ctx, d, meta)
+	}
+
 	provider := meta.(*tsuruProvider)
 	name := d.Get("name").(string)
 	serviceName := d.Get("service_name").(string)
@@ -213,9 +236,7 @@ func resourceTsuruServiceInstanceUpdate(ctx context.Context, d *schema.ResourceD
 		instanceData.Description = description.(string)
 	}
 
-	if tags, ok := d.GetOk("tags"); ok {
-		instanceData.Tags = parseTags(tags)
-	}
+	instanceData.Tags = mergeDefaultTags(provider, parseTags(d.Get("tags")))
 
 	if parameters, ok := d.GetOk("parameters"); ok {
 		instanceData.Parameters = parseParameters(parameters)
@@ -223,17 +244,15 @@ func resourceTsuruServiceInstanceUpdate(ctx context.Context, d *schema.ResourceD
 
 	_, err := provider.TsuruClient.ServiceApi.InstanceUpdate(ctx, serviceName, name, instanceData)
 	if err != nil {
-		return diag.Errorf("Could not update tsuru service instance: %q, err: %s", d.Id(), err.Error())
+		return tsuruDiagnostics(err, "Could not update tsuru service instance %q", d.Id())
 	}
 
 	if waitForStatus, ok := d.GetOk("wait_for_up_status"); ok {
 		if waitForStatus.(bool) {
 			log.Printf("[INFO] Waiting for service_instance %s/%s to reach up status", serviceName, name)
-			err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-				waitForServiceInstanceStatusUpFunc(ctx, provider, serviceName, name),
-			)
+			err := waitForServiceInstanceStatusUp(ctx, provider, serviceName, name, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return diag.FromErr(err)
+				return tsuruDiagnostics(err, "service instance %s/%s did not reach up status", serviceName, name)
 			}
 		}
 	}
@@ -247,9 +266,13 @@ func resourceTsuruServiceInstanceDelete(ctx context.Context, d *schema.ResourceD
 	serviceName := d.Get("service_name").(string)
 	unbind := d.Get("unbind_on_delete").(bool)
 
+	if diags := checkDeletionProtection(provider, d, "service instance", name); diags != nil {
+		return diags
+	}
+
 	_, err := provider.TsuruClient.ServiceApi.InstanceDelete(ctx, serviceName, name, unbind)
 	if err != nil {
-		return diag.Errorf("Could not delete tsuru service instance, err: %s", err.Error())
+		return tsuruDiagnostics(err, "Could not delete tsuru service instance")
 	}
 
 	return nil
@@ -270,11 +293,11 @@ func serviceInstanceStatus(ctx context.Context, provider *tsuruProvider, service
 	return string(b), nil
 }
 
-func waitForServiceInstanceStatusUpFunc(ctx context.Context, provider *tsuruProvider, serviceName, serviceInstance string) resource.RetryFunc {
-	return func() *resource.RetryError {
+func waitForServiceInstanceStatusUp(ctx context.Context, provider *tsuruProvider, serviceName, serviceInstance string, timeout time.Duration) error {
+	return tsuruRetryWhen(ctx, provider, timeout, isServiceInstanceNotUpError, func() error {
 		currentStatus, err := serviceInstanceStatus(ctx, provider, serviceName, serviceInstance)
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return err
 		}
 
 		if strings.HasSuffix(currentStatus, "is up") {
@@ -283,8 +306,23 @@ func waitForServiceInstanceStatusUpFunc(ctx context.Context, provider *tsuruProv
 		}
 
 		log.Printf("[INFO] service %s/%s current status %q ", serviceName, serviceInstance, currentStatus)
-		return resource.RetryableError(fmt.Errorf("current status %q", currentStatus))
-	}
+		return &serviceInstanceNotUpError{status: currentStatus}
+	})
+}
+
+// serviceInstanceNotUpError is the status of a service instance still not
+// up, it is retried until the timeout.
+type serviceInstanceNotUpError struct {
+	status string
+}
+
+func (e *serviceInstanceNotUpError) Error() string {
+	return fmt.Sprintf("current status %q", e.status)
+}
+
+func isServiceInstanceNotUpError(err error) bool {
+	var notUp *serviceInstanceNotUpError
+	return errors.As(err, &notUp)
 }
 
 func parseTags(data any) []string {
@@ -11,9 +11,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
@@ -92,7 +90,17 @@ func resourceTsuruServiceInstanceBindCreate(ctx context.Context, d *schema.Resou
 		idToSet = createID([]string{service, instance, "tsuru-job", jobName})
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	target := appLock(appName)
+	if jobName != "" {
+		target = jobLock(jobName)
+	}
+	unlock, err := provider.locks.lock(ctx, target, serviceInstanceLock(service, instance))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instance, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		var resp *http.Response
 		var err error
 		if appName != "" {
@@ -103,21 +111,14 @@ func resourceTsuruServiceInstanceBindCreate(ctx context.Context, d *schema.Resou
 			resp, err = provider.TsuruClient.ServiceApi.JobServiceInstanceBind(ctx, service, instance, jobName, tsuru_client.JobServiceInstanceBind{})
 		}
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
+			return err
 		}
 		defer resp.Body.Close()
 		logTsuruStream(resp.Body)
 		return nil
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to bind service instance %s to %s", instance, bindTarget(appName, jobName))
 	}
 
 	d.SetId(idToSet)
@@ -150,7 +151,7 @@ func resourceTsuruServiceInstanceBindRead(ctx context.Context, d *schema.Resourc
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read bind %s %s: %v", service, instanceName, err)
+		return tsuruDiagnostics(err, "unable to read bind %s %s", service, instanceName)
 	}
 
 	for _, a := range instance.Apps {
@@ -195,7 +196,17 @@ func resourceTsuruServiceInstanceBindDelete(ctx context.Context, d *schema.Resou
 		noRestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	target := appLock(appName)
+	if jobName != "" {
+		target = jobLock(jobName)
+	}
+	unlock, err := provider.locks.lock(ctx, target, serviceInstanceLock(service, instance))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instance, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		var err error
 		if appName != "" {
 			_, err = provider.TsuruClient.ServiceApi.ServiceInstanceUnbind(ctx, service, instance, appName, tsuru_client.ServiceInstanceUnbind{
@@ -204,21 +215,18 @@ func resourceTsuruServiceInstanceBindDelete(ctx context.Context, d *schema.Resou
 		} else {
 			_, err = provider.TsuruClient.ServiceApi.JobServiceInstanceUnbind(ctx, service, instance, jobName, tsuru_client.JobServiceInstanceUnbind{})
 		}
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+		return err
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to unbind service instance %s from %s", instance, bindTarget(appName, jobName))
 	}
 
 	return nil
 }
+
+func bindTarget(appName, jobName string) string {
+	if jobName != "" {
+		return "job " + jobName
+	}
+	return "app " + appName
+}
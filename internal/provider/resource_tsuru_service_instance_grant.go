@@ -9,10 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
-	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
 func resourceTsuruServiceInstanceGrant() *schema.Resource {
@@ -58,22 +55,19 @@ func resourceTsuruServiceInstanceGrantCreate(ctx context.Context, d *schema.Reso
 	instance := d.Get("service_instance").(string)
 	team := d.Get("team").(string)
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, serviceInstanceLock(service, instance))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instance, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		_, err := provider.TsuruClient.ServiceApi.ServiceInstanceGrant(ctx, service, instance, team)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(errors.Errorf("unable to grant permission to team %s on %s %s: %v", team, service, instance, err))
-		}
-		return nil
+		return err
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to grant permission to team %s on %s %s", team, service, instance)
 	}
 
 	d.SetId(createID([]string{service, instance, team}))
@@ -94,7 +88,7 @@ func resourceTsuruServiceInstanceGrantRead(ctx context.Context, d *schema.Resour
 
 	instance, _, err := provider.TsuruClient.ServiceApi.InstanceGet(ctx, service, instanceName)
 	if err != nil {
-		return diag.Errorf("unable to read bind %s %s: %v", service, instanceName, err)
+		return tsuruDiagnostics(err, "unable to read bind %s %s", service, instanceName)
 	}
 
 	for _, t := range instance.Teams {
@@ -116,9 +110,15 @@ func resourceTsuruServiceInstanceGrantDelete(ctx context.Context, d *schema.Reso
 	instance := d.Get("service_instance").(string)
 	team := d.Get("team").(string)
 
-	_, err := provider.TsuruClient.ServiceApi.ServiceInstanceRevoke(ctx, service, instance, team)
+	unlock, err := provider.locks.lock(ctx, serviceInstanceLock(service, instance))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instance, err)
+	}
+	defer unlock()
+
+	_, err = provider.TsuruClient.ServiceApi.ServiceInstanceRevoke(ctx, service, instance, team)
 	if err != nil {
-		return diag.Errorf("unable to revoke permission to team %s on %s %s: %v", team, service, instance, err)
+		return tsuruDiagnostics(err, "unable to revoke permission to team %s on %s %s", team, service, instance)
 	}
 
 	return nil
@@ -0,0 +1,193 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceTsuruServiceInstanceTeams() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Service Instance Teams, authoritative list of the teams with access to a service instance, teams granted outside of it are revoked",
+		CreateContext: resourceTsuruServiceInstanceTeamsSet,
+		ReadContext:   resourceTsuruServiceInstanceTeamsRead,
+		UpdateContext: resourceTsuruServiceInstanceTeamsSet,
+		DeleteContext: resourceTsuruServiceInstanceTeamsDelete,
+		CustomizeDiff: resourceTsuruServiceInstanceTeamsCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of service kind",
+			},
+			"service_instance": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of service instance",
+			},
+			"teams": {
+				Type:        schema.TypeSet,
+				Description: "Teams with access to the service instance, must include the team owner of the instance",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTsuruServiceInstanceTeamsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"service_name", "service_instance", "teams"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	provider := meta.(*tsuruProvider)
+	service := d.Get("service_name").(string)
+	instanceName := d.Get("service_instance").(string)
+
+	instance, _, err := provider.TsuruClient.ServiceApi.InstanceGet(ctx, service, instanceName)
+	if err != nil {
+		// the instance may be created on the same plan
+		return nil
+	}
+
+	return checkTeamOwnerKept("service instance", service+" "+instanceName, instance.Teamowner, setToStringSlice(d.Get("teams").(*schema.Set)))
+}
+
+func resourceTsuruServiceInstanceTeamsSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	service := d.Get("service_name").(string)
+	instanceName := d.Get("service_instance").(string)
+
+	unlock, err := provider.locks.lock(ctx, serviceInstanceLock(service, instanceName))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instanceName, err)
+	}
+	defer unlock()
+
+	instance, _, err := provider.TsuruClient.ServiceApi.InstanceGet(ctx, service, instanceName)
+	if err != nil {
+		return tsuruDiagnostics(err, "unable to read service instance %s %s", service, instanceName)
+	}
+
+	desired := setToStringSlice(d.Get("teams").(*schema.Set))
+	if err = checkTeamOwnerKept("service instance", service+" "+instanceName, instance.Teamowner, desired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	grant, revoke := diffStringSets(instance.Teams, desired)
+
+	for _, team := range grant {
+		if err = grantServiceInstanceTeam(ctx, provider, service, instanceName, team, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, team := range revoke {
+		if err = revokeServiceInstanceTeam(ctx, provider, service, instanceName, team, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(createID([]string{service, instanceName}))
+
+	return resourceTsuruServiceInstanceTeamsRead(ctx, d, meta)
+}
+
+func resourceTsuruServiceInstanceTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+
+	parts, err := IDtoParts(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	service := parts[0]
+	instanceName := parts[1]
+
+	instance, _, err := provider.TsuruClient.ServiceApi.InstanceGet(ctx, service, instanceName)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read service instance %s %s", service, instanceName)
+	}
+
+	d.Set("service_name", service)
+	d.Set("service_instance", instanceName)
+	d.Set("teams", instance.Teams)
+
+	return nil
+}
+
+func resourceTsuruServiceInstanceTeamsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	service := d.Get("service_name").(string)
+	instanceName := d.Get("service_instance").(string)
+
+	unlock, err := provider.locks.lock(ctx, serviceInstanceLock(service, instanceName))
+	if err != nil {
+		return diag.Errorf("unable to lock service instance %s: %v", instanceName, err)
+	}
+	defer unlock()
+
+	instance, _, err := provider.TsuruClient.ServiceApi.InstanceGet(ctx, service, instanceName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read service instance %s %s", service, instanceName)
+	}
+
+	for _, team := range instance.Teams {
+		if team == instance.Teamowner {
+			continue
+		}
+		if err = revokeServiceInstanceTeam(ctx, provider, service, instanceName, team, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func grantServiceInstanceTeam(ctx context.Context, provider *tsuruProvider, service, instance, team string, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.ServiceApi.ServiceInstanceGrant(ctx, service, instance, team)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to grant permission to team %s on %s %s", team, service, instance)
+	}
+	return nil
+}
+
+func revokeServiceInstanceTeam(ctx context.Context, provider *tsuruProvider, service, instance, team string, timeout time.Duration) error {
+	err := tsuruRetry(ctx, provider, timeout, func() error {
+		_, err := provider.TsuruClient.ServiceApi.ServiceInstanceRevoke(ctx, service, instance, team)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to revoke permission to team %s on %s %s", team, service, instance)
+	}
+	return nil
+}
@@ -86,6 +86,68 @@ func TestTsuruServiceInstance_basic(t *testing.T) {
 	})
 }
 
+func TestTsuruServiceInstance_providerDefaults(t *testing.T) {
+	fakeServer := echo.New()
+
+	var instance *tsuru.ServiceInstance
+	fakeServer.POST("/1.0/services/mysql/instances", func(c echo.Context) error {
+		si := &tsuru.ServiceInstance{}
+		err := c.Bind(&si)
+		require.NoError(t, err)
+		assert.Equal(t, "my-team", si.TeamOwner)
+		// default_pool is only used by apps, jobs and volumes
+		assert.Equal(t, "", si.Pool)
+		instance = si
+		return nil
+	})
+	fakeServer.GET("/1.0/services/mysql/instances/db01", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.ServiceInstanceInfo{
+			Teamowner: instance.TeamOwner,
+			Planname:  instance.PlanName,
+			Pool:      instance.Pool,
+		})
+	})
+	fakeServer.GET("/1.0/services/mysql/instances/db01/status", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Service is up")
+	})
+	fakeServer.DELETE("/1.0/services/mysql/instances/db01", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_service_instance.db"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	provider "tsuru" {
+		default_team_owner = "my-team"
+		default_pool       = "pool01"
+	}
+
+	resource "tsuru_service_instance" "db" {
+		service_name = "mysql"
+		name         = "db01"
+		plan         = "small"
+	}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owner", "my-team"),
+					resource.TestCheckResourceAttr(resourceName, "pool", ""),
+				),
+			},
+		},
+	})
+}
+
 func testAccTsuruServiceInstanceConfig_basic(fakeServer, name string) string {
 	return fmt.Sprintf(`
 resource "tsuru_service_instance"  "my_reverse_proxy"   {
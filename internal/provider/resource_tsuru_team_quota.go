@@ -0,0 +1,107 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTsuruTeamQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru Team Quota",
+		CreateContext: resourceTsuruTeamQuotaCreate,
+		ReadContext:   resourceTsuruTeamQuotaRead,
+		UpdateContext: resourceTsuruTeamQuotaUpdate,
+		DeleteContext: resourceTsuruTeamQuotaDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:        schema.TypeString,
+				Description: "Team name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of apps owned by the team, -1 means unlimited. The quota goes back to unlimited when this resource is destroyed",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(unlimitedQuota),
+			},
+			"in_use": {
+				Type:        schema.TypeInt,
+				Description: "Number of apps owned by the team",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTsuruTeamQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	team := d.Get("team").(string)
+
+	if err := changeTeamQuota(ctx, provider, team, d.Get("limit").(int), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of team %s", team)
+	}
+
+	d.SetId(team)
+
+	return resourceTsuruTeamQuotaRead(ctx, d, meta)
+}
+
+func resourceTsuruTeamQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	team := d.Id()
+
+	quota, err := getTeamQuota(ctx, provider, team)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read quota of team %s", team)
+	}
+
+	d.Set("team", team)
+	flattenQuota(d, quota)
+
+	return nil
+}
+
+func resourceTsuruTeamQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	team := d.Id()
+
+	if err := changeTeamQuota(ctx, provider, team, d.Get("limit").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of team %s", team)
+	}
+
+	return resourceTsuruTeamQuotaRead(ctx, d, meta)
+}
+
+// resourceTsuruTeamQuotaDelete sets the quota back to unlimited.
+func resourceTsuruTeamQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	team := d.Id()
+
+	err := changeTeamQuota(ctx, provider, team, unlimitedQuota, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !isNotFoundError(err) {
+		return tsuruDiagnostics(err, "unable to reset quota of team %s", team)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+func TestAccResourceTsuruTeamQuota(t *testing.T) {
+	fakeServer := echo.New()
+
+	quota := tsuru.UserQuotaViewResponse{Limit: unlimitedQuota, Inuse: 2}
+	limits := []string{}
+
+	fakeServer.GET("/1.12/teams/:team/quota", func(c echo.Context) error {
+		assert.Equal(t, "my-team", c.Param("team"))
+		return c.JSON(http.StatusOK, quota)
+	})
+
+	fakeServer.PUT("/1.12/teams/:team/quota", func(c echo.Context) error {
+		assert.Equal(t, "my-team", c.Param("team"))
+		limit, err := strconv.Atoi(c.FormValue("limit"))
+		assert.NoError(t, err)
+		limits = append(limits, c.FormValue("limit"))
+		quota.Limit = int32(limit)
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_team_quota.quota"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			assert.Equal(t, []string{"5", "-1"}, limits)
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTsuruTeamQuota(5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "team", "my-team"),
+					resource.TestCheckResourceAttr(resourceName, "limit", "5"),
+					resource.TestCheckResourceAttr(resourceName, "in_use", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTsuruTeamQuota(limit int) string {
+	return fmt.Sprintf(`
+	resource "tsuru_team_quota" "quota" {
+		team  = "my-team"
+		limit = %d
+	}
+`, limit)
+}
@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -129,23 +127,17 @@ func resourceTsuruTokenCreate(ctx context.Context, d *schema.ResourceData, meta
 		teamToken.ExpiresIn = int64(duration.Seconds())
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err := tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		token, _, err := provider.TsuruClient.AuthApi.TeamTokenCreate(ctx, teamToken)
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
+			return err
 		}
 		d.SetId(token.TokenId)
 		return nil
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to create token for team %s", teamToken.Team)
 	}
 
 	return resourceTsuruTokenRead(ctx, d, meta)
@@ -161,7 +153,7 @@ func resourceTsuruTokenRead(ctx context.Context, d *schema.ResourceData, meta in
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read token %s: %v", tokenId, err)
+		return tsuruDiagnostics(err, "unable to read token %s", tokenId)
 	}
 
 	d.Set("token", teamToken.Token)
@@ -198,22 +190,19 @@ func resourceTsuruTokenUpdate(ctx context.Context, d *schema.ResourceData, meta
 		teamToken.ExpiresIn = int64(duration.Seconds())
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		_, _, err := provider.TsuruClient.AuthApi.TeamTokenUpdate(ctx, tokenId, teamToken)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+	// regenerating the token is not idempotent
+	updateCtx := ctx
+	if !teamToken.Regenerate {
+		updateCtx = withIdempotentRequest(ctx)
+	}
+
+	err := tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutUpdate), func() error {
+		_, _, err := provider.TsuruClient.AuthApi.TeamTokenUpdate(updateCtx, tokenId, teamToken)
+		return err
 	})
 
 	if err != nil {
-		return diag.Errorf("unable to update token %s: %v", tokenId, err)
+		return tsuruDiagnostics(err, "unable to update token %s", tokenId)
 	}
 
 	return resourceTsuruTokenRead(ctx, d, meta)
@@ -223,22 +212,13 @@ func resourceTsuruTokenDelete(ctx context.Context, d *schema.ResourceData, meta
 	provider := meta.(*tsuruProvider)
 	tokenId := d.Id()
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	err := tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
 		_, err := provider.TsuruClient.AuthApi.TeamTokenDelete(ctx, tokenId)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
+		return err
 	})
 
 	if err != nil {
-		return diag.Errorf("unable to delete token %s: %v", tokenId, err)
+		return tsuruDiagnostics(err, "unable to delete token %s", tokenId)
 	}
 
 	return nil
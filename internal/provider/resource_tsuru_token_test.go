@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
@@ -153,6 +154,69 @@ func TestAccResourceTsuruToken(t *testing.T) {
 
 }
 
+func TestAccResourceTsuruToken_connectionClosed(t *testing.T) {
+	fakeServer := echo.New()
+
+	fakeServer.POST("/1.6/tokens", func(c echo.Context) error {
+		teamToken := tsuru.TeamTokenCreateArgs{}
+		c.Bind(&teamToken)
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"status":   "success",
+			"token_id": teamToken.TokenId,
+		})
+	})
+
+	fakeServer.GET("/1.7/tokens/:token", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tsuru.TeamToken{
+			Token:       "string-token",
+			TokenId:     c.Param("token"),
+			Description: "My description",
+			Team:        "team-dev",
+		})
+	})
+
+	fakeServer.PUT("/1.6/tokens/:token", func(c echo.Context) error {
+		return closeConnection(c)
+	})
+
+	fakeServer.DELETE("/1.6/tokens/:token", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	provider := `
+	provider "tsuru" {
+		max_retries = 0
+	}
+`
+
+	resourceName := "tsuru_token.team_token"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccResourceTsuruToken_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", "My description"),
+				),
+			},
+			{
+				Config:      provider + testAccResourceTsuruToken_complete(),
+				ExpectError: regexp.MustCompile(`(?s)unable to update token my-simple-token.*unable to reach the tsuru API`),
+			},
+		},
+	})
+}
+
 func testAccResourceTsuruToken_basic() string {
 	return `
 	resource "tsuru_token" "team_token" {
@@ -0,0 +1,107 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTsuruUserQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Tsuru User Quota",
+		CreateContext: resourceTsuruUserQuotaCreate,
+		ReadContext:   resourceTsuruUserQuotaRead,
+		UpdateContext: resourceTsuruUserQuotaUpdate,
+		DeleteContext: resourceTsuruUserQuotaDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Description: "User email",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of apps owned by the user, -1 means unlimited. The quota goes back to unlimited when this resource is destroyed",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(unlimitedQuota),
+			},
+			"in_use": {
+				Type:        schema.TypeInt,
+				Description: "Number of apps owned by the user",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTsuruUserQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	email := d.Get("email").(string)
+
+	if err := changeUserQuota(ctx, provider, email, d.Get("limit").(int), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of user %s", email)
+	}
+
+	d.SetId(email)
+
+	return resourceTsuruUserQuotaRead(ctx, d, meta)
+}
+
+func resourceTsuruUserQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	email := d.Id()
+
+	quota, err := getUserQuota(ctx, provider, email)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return tsuruDiagnostics(err, "unable to read quota of user %s", email)
+	}
+
+	d.Set("email", email)
+	flattenQuota(d, quota)
+
+	return nil
+}
+
+func resourceTsuruUserQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	email := d.Id()
+
+	if err := changeUserQuota(ctx, provider, email, d.Get("limit").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return tsuruDiagnostics(err, "unable to set quota of user %s", email)
+	}
+
+	return resourceTsuruUserQuotaRead(ctx, d, meta)
+}
+
+// resourceTsuruUserQuotaDelete sets the quota back to unlimited.
+func resourceTsuruUserQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	provider := meta.(*tsuruProvider)
+	email := d.Id()
+
+	err := changeUserQuota(ctx, provider, email, unlimitedQuota, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !isNotFoundError(err) {
+		return tsuruDiagnostics(err, "unable to reset quota of user %s", email)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ func resourceTsuruVolume() *schema.Resource {
 		Description:   "Tsuru Service Volume",
 		CreateContext: resourceTsuruVolumeCreate,
 		ReadContext:   resourceTsuruVolumeRead,
+		UpdateContext: resourceTsuruVolumeRead,
 		DeleteContext: resourceTsuruVolumeDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -27,6 +28,9 @@ func resourceTsuruVolume() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return setProviderDefaults(d, meta.(*tsuruProvider), "owner", "pool")
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -41,15 +45,17 @@ func resourceTsuruVolume() *schema.Resource {
 			},
 			"owner": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "Team owner of this volume",
+				Description: "Team owner of this volume, defaults to the provider default_team_owner on creation",
 			},
 			"pool": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "Volume Pool",
+				Description: "Volume Pool, defaults to the provider default_pool on creation",
 			},
 			"options": {
 				Type:        schema.TypeMap,
@@ -58,6 +64,12 @@ func resourceTsuruVolume() *schema.Resource {
 				ForceNew:    true,
 				Description: "Volume additional options",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Prevent the volume from being destroyed, it must be set to false and applied before destroying the volume",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -88,7 +100,7 @@ func resourceTsuruVolumeCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	_, err := provider.TsuruClient.VolumeApi.VolumeCreate(ctx, volume)
 	if err != nil {
-		return diag.Errorf("Unable to create volume: %v", err)
+		return tsuruDiagnostics(err, "Unable to create volume")
 	}
 
 	d.SetId(volume.Name)
@@ -107,7 +119,7 @@ func resourceTsuruVolumeRead(ctx context.Context, d *schema.ResourceData, meta i
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("Unable to read volume: %v", err)
+		return tsuruDiagnostics(err, "Unable to read volume")
 	}
 
 	d.Set("name", volume.Name)
@@ -124,9 +136,13 @@ func resourceTsuruVolumeDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	name := d.Get("name").(string)
 
+	if diags := checkDeletionProtection(provider, d, "volume", name); diags != nil {
+		return diags
+	}
+
 	_, err := provider.TsuruClient.VolumeApi.VolumeDelete(ctx, name)
 	if err != nil {
-		return diag.Errorf("Unable to delete volume: %v", err)
+		return tsuruDiagnostics(err, "Unable to delete volume")
 	}
 
 	return nil
@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
@@ -90,29 +88,28 @@ func resourceTsuruVolumeBindCreate(ctx context.Context, d *schema.ResourceData,
 		bindData.Norestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(bindData.App))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", bindData.App, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutCreate), func() error {
 		resp, err := provider.TsuruClient.VolumeApi.VolumeBind(ctx, name, bindData)
 		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
+			return err
 		}
 
 		defer resp.Body.Close()
 		logTsuruStream(resp.Body)
-
-		d.SetId(createID([]string{bindData.App, name, bindData.Mountpoint}))
 		return nil
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to bind volume %s to app %s", name, bindData.App)
 	}
 
+	d.SetId(createID([]string{bindData.App, name, bindData.Mountpoint}))
+
 	return resourceTsuruVolumeBindRead(ctx, d, meta)
 }
 
@@ -133,7 +130,7 @@ func resourceTsuruVolumeBindRead(ctx context.Context, d *schema.ResourceData, me
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to read volume info: %v", err)
+		return tsuruDiagnostics(err, "unable to read volume info")
 	}
 
 	for _, bind := range volume.Binds {
@@ -174,22 +171,18 @@ func resourceTsuruVolumeBindDelete(ctx context.Context, d *schema.ResourceData,
 		bindData.Norestart = true
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	unlock, err := provider.locks.lock(ctx, appLock(bindData.App))
+	if err != nil {
+		return diag.Errorf("unable to lock app %s: %v", bindData.App, err)
+	}
+	defer unlock()
+
+	err = tsuruRetry(ctx, provider, d.Timeout(schema.TimeoutDelete), func() error {
 		_, err := provider.TsuruClient.VolumeApi.VolumeUnbind(ctx, name, bindData)
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-		}
-		return nil
+		return err
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return tsuruDiagnostics(err, "unable to unbind volume %s from app %s", name, bindData.App)
 	}
 
 	return nil
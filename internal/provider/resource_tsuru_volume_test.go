@@ -90,3 +90,59 @@ func testAccResourceVolume_basic() string {
 	}
 `
 }
+
+func TestAccResourceVolume_providerDefaults(t *testing.T) {
+	fakeServer := echo.New()
+
+	var volume *tsuru.Volume
+	fakeServer.POST("/1.4/volumes", func(c echo.Context) error {
+		v := tsuru.Volume{}
+		c.Bind(&v)
+		assert.Equal(t, "my-team", v.TeamOwner)
+		assert.Equal(t, "pool01", v.Pool)
+		volume = &v
+		return c.JSON(http.StatusOK, map[string]interface{}{"ok": "true"})
+	})
+
+	fakeServer.GET("/1.4/volumes/:volume", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, volume)
+	})
+
+	fakeServer.DELETE("/1.4/volumes/:volume", func(c echo.Context) error {
+		volume = nil
+		return c.NoContent(http.StatusOK)
+	})
+
+	fakeServer.HTTPErrorHandler = func(err error, c echo.Context) {
+		t.Errorf("methods=%s, path=%s, err=%s", c.Request().Method, c.Path(), err.Error())
+	}
+	server := httptest.NewServer(fakeServer)
+	os.Setenv("TSURU_TARGET", server.URL)
+
+	resourceName := "tsuru_volume.volume"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	provider "tsuru" {
+		default_team_owner = "my-team"
+		default_pool       = "pool01"
+	}
+
+	resource "tsuru_volume" "volume" {
+		name = "volume01"
+		plan = "plan01"
+	}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "owner", "my-team"),
+					resource.TestCheckResourceAttr(resourceName, "pool", "pool01"),
+				),
+			},
+		},
+	})
+}
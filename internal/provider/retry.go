@@ -0,0 +1,220 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
+)
+
+// retryPolicy is how the provider retries the requests failed with
+// transient errors and the actions failed with event locked.
+type retryPolicy struct {
+	// MaxRetries is the number of retries of a request failed with a
+	// transient error, actions failed with event locked are retried until
+	// the timeout of the resource.
+	MaxRetries int
+	// BaseWait is the wait before the first retry, it doubles on each retry.
+	BaseWait time.Duration
+	// MaxWait caps the wait between retries, including Retry-After.
+	MaxWait time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	MaxRetries: 3,
+	BaseWait:   500 * time.Millisecond,
+	MaxWait:    30 * time.Second,
+}
+
+// wait returns the wait before the retry after attempt, it grows
+// exponentially with jitter so concurrent resources do not retry together.
+func (p retryPolicy) wait(attempt int) time.Duration {
+	wait := p.BaseWait
+	for i := 0; i < attempt && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter returns the wait asked by the Retry-After header of resp,
+// capped by MaxWait.
+func (p retryPolicy) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	return wait, true
+}
+
+type idempotentRequestKey struct{}
+
+// withIdempotentRequest marks the writes sent with ctx as safe to be sent
+// again, like setting all envs of an app, so they are retried on transient
+// errors as reads are.
+func withIdempotentRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentRequestKey{}, true)
+}
+
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentRequestKey{}).(bool)
+	return idempotent
+}
+
+// isTransientError reports whether the request may succeed when sent again,
+// on connection errors, timeouts, rate limits and unavailable gateways.
+func isTransientError(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryTransport sends again the reads and idempotent writes failed with
+// transient errors.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy *retryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := isIdempotentRequest(req) && (req.Body == nil || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if !retryable || attempt >= t.policy.MaxRetries || !isTransientError(resp, err) {
+			return resp, err
+		}
+
+		wait, ok := t.policy.retryAfter(resp)
+		if !ok {
+			wait = t.policy.wait(attempt)
+		}
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s failed with status code %d, retrying in %s", req.Method, req.URL.Path, resp.StatusCode, wait)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %v, retrying in %s", req.Method, req.URL.Path, err, wait)
+		}
+
+		if err = sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// tsuruRetry calls f until it does not fail with event locked, waiting
+// between the calls as the provider retry policy. The last error is returned
+// when the timeout expires.
+func tsuruRetry(ctx context.Context, provider *tsuruProvider, timeout time.Duration, f func() error) error {
+	return tsuruRetryWhen(ctx, provider, timeout, isEventLockedError, f)
+}
+
+// tsuruRetryWhen is tsuruRetry for the actions with failures other than event
+// locked that go away with time, retryable tells which errors are retried.
+func tsuruRetryWhen(ctx context.Context, provider *tsuruProvider, timeout time.Duration, retryable func(error) bool, f func() error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		err := f()
+		if err == nil || !retryable(err) {
+			return err
+		}
+
+		wait := provider.retry.wait(attempt)
+		log.Printf("[DEBUG] %v, retrying in %s", err, wait)
+		if sleepContext(ctx, wait) != nil {
+			return err
+		}
+	}
+}
+
+func isEventLockedError(err error) bool {
+	var apiError tsuru_client.GenericOpenAPIError
+	if errors.As(err, &apiError) {
+		return isRetryableError(apiError.Body())
+	}
+	var requestErr *tsuruRequestError
+	if errors.As(err, &requestErr) {
+		return isRetryableError([]byte(requestErr.Message))
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
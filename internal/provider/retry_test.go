@@ -0,0 +1,195 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyWait(t *testing.T) {
+	policy := retryPolicy{BaseWait: 100 * time.Millisecond, MaxWait: time.Second}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			wait := policy.wait(attempt)
+			assert.GreaterOrEqual(t, wait, max/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, wait, max, "attempt %d", attempt)
+		}
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	policy := retryPolicy{MaxWait: 10 * time.Second}
+
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+
+	wait, ok := policy.retryAfter(header("3"))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = policy.retryAfter(header("120"))
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, wait)
+
+	wait, ok = policy.retryAfter(header(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = policy.retryAfter(header("soon"))
+	assert.False(t, ok)
+
+	_, ok = policy.retryAfter(&http.Response{Header: http.Header{}})
+	assert.False(t, ok)
+
+	_, ok = policy.retryAfter(nil)
+	assert.False(t, ok)
+}
+
+func TestIsTransientError(t *testing.T) {
+	status := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+
+	assert.True(t, isTransientError(status(http.StatusTooManyRequests), nil))
+	assert.True(t, isTransientError(status(http.StatusBadGateway), nil))
+	assert.True(t, isTransientError(status(http.StatusServiceUnavailable), nil))
+	assert.True(t, isTransientError(status(http.StatusGatewayTimeout), nil))
+	assert.False(t, isTransientError(status(http.StatusOK), nil))
+	assert.False(t, isTransientError(status(http.StatusInternalServerError), nil))
+	assert.False(t, isTransientError(status(http.StatusConflict), nil))
+
+	assert.True(t, isTransientError(nil, &net.OpError{Op: "read", Err: syscall.ECONNRESET}))
+	assert.True(t, isTransientError(nil, &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}))
+	assert.True(t, isTransientError(nil, io.ErrUnexpectedEOF))
+	assert.True(t, isTransientError(nil, &net.DNSError{IsTimeout: true}))
+	assert.False(t, isTransientError(nil, context.Canceled))
+	assert.False(t, isTransientError(nil, errors.New("x509: certificate signed by unknown authority")))
+}
+
+func TestRetryTransport(t *testing.T) {
+	var calls int32
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := &retryPolicy{MaxRetries: 3, BaseWait: time.Millisecond, MaxWait: time.Millisecond}
+	client := &http.Client{Transport: &retryTransport{base: http.DefaultTransport, policy: policy}}
+
+	t.Run("reads are retried", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("writes are not retried", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("units=1"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("idempotent writes are retried with their body", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		bodies = []string{}
+		req, err := http.NewRequestWithContext(withIdempotentRequest(context.Background()), http.MethodPost, server.URL, strings.NewReader("envs"))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"envs", "envs", "envs"}, bodies)
+	})
+
+	t.Run("retries are limited", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		client := &http.Client{Transport: &retryTransport{base: http.DefaultTransport, policy: &retryPolicy{MaxRetries: 1}}}
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestRetryTransportConnectionErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := &retryPolicy{MaxRetries: 1, BaseWait: time.Millisecond, MaxWait: time.Millisecond}
+	client := &http.Client{Transport: &retryTransport{base: &http.Transport{DisableKeepAlives: true}, policy: policy}}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestTsuruRetry(t *testing.T) {
+	provider := &tsuruProvider{retry: retryPolicy{BaseWait: time.Millisecond, MaxWait: time.Millisecond}}
+
+	calls := 0
+	err := tsuruRetry(context.Background(), provider, time.Minute, func() error {
+		calls++
+		if calls < 3 {
+			return &tsuruRequestError{StatusCode: http.StatusConflict, Message: "event locked"}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = tsuruRetry(context.Background(), provider, time.Minute, func() error {
+		calls++
+		return &tsuruRequestError{StatusCode: http.StatusBadRequest, Message: "invalid pool"}
+	})
+	assert.EqualError(t, err, "status code: 400, message: invalid pool")
+	assert.Equal(t, 1, calls)
+
+	err = tsuruRetry(context.Background(), provider, 20*time.Millisecond, func() error {
+		return &tsuruRequestError{StatusCode: http.StatusConflict, Message: "event locked"}
+	})
+	assert.EqualError(t, err, "status code: 409, message: event locked")
+}
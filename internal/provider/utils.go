@@ -5,12 +5,15 @@
 package provider
 
 import (
-	"context"
+	"fmt"
 	"net/http"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
@@ -36,6 +39,10 @@ func isNotFoundError(err error) bool {
 	if err == nil {
 		return false
 	}
+	var requestErr *tsuruRequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.StatusCode == http.StatusNotFound
+	}
 	openAPIError, ok := err.(tsuru_client.GenericOpenAPIError)
 	return ok && openAPIError.StatusCode() == http.StatusNotFound
 }
@@ -45,22 +52,6 @@ func isRetryableError(err []byte) bool {
 	return strings.Contains(e, "event locked")
 }
 
-func tsuruRetry(ctx context.Context, d *schema.ResourceData, f func() error) error {
-	return resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		err := f()
-		if err != nil {
-			var apiError tsuru_client.GenericOpenAPIError
-			if errors.As(err, &apiError) {
-				if isRetryableError(apiError.Body()) {
-					return resource.RetryableError(err)
-				}
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
-	})
-}
-
 func createID(input []string) string {
 	return strings.TrimSpace(strings.Join(input, ID_SEPARATOR))
 }
@@ -160,3 +151,223 @@ func checkProcessesListsChanges(oldProcesses []tsuru_client.AppProcess, newProce
 
 	return onlyInOldList, onlyInNewList, inBoth
 }
+
+// checkDeletionProtection returns an error when the resource has
+// deletion_protection enabled or is in one of the protected_pools of the
+// provider.
+func checkDeletionProtection(provider *tsuruProvider, d *schema.ResourceData, kind, name string) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s %s has deletion protection enabled", kind, name),
+			Detail:        fmt.Sprintf("Set deletion_protection to false and apply it before destroying or replacing the %s.", kind),
+			AttributePath: cty.GetAttrPath("deletion_protection"),
+		}}
+	}
+
+	pool := d.Get("pool").(string)
+	for _, protected := range provider.ProtectedPools {
+		if pool != "" && pool == protected {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s %s is in the protected pool %s", kind, name, pool),
+				Detail:   fmt.Sprintf("Remove %s from the protected_pools of the provider before destroying or replacing the %s.", pool, kind),
+			}}
+		}
+	}
+
+	return nil
+}
+
+// mergeDefaultTags returns the tags followed by the default_tags of the
+// provider missing on them.
+func mergeDefaultTags(provider *tsuruProvider, tags []string) []string {
+	result := append([]string{}, tags...)
+	for _, tag := range provider.DefaultTags {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// withoutDefaultTags removes the default_tags of the provider from the tags
+// read from tsuru, unless they are also configured on the resource.
+func withoutDefaultTags(provider *tsuruProvider, tags, configured []string) []string {
+	result := []string{}
+	for _, tag := range tags {
+		if slices.Contains(provider.DefaultTags, tag) && !slices.Contains(configured, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// mergeDefaultMetadata returns the metadata with the default_metadata of the
+// provider, items of the resource win over the defaults with the same name.
+func mergeDefaultMetadata(provider *tsuruProvider, metadata *tsuru_client.Metadata) *tsuru_client.Metadata {
+	if metadata == nil {
+		metadata = &tsuru_client.Metadata{}
+	}
+
+	merged := &tsuru_client.Metadata{
+		Labels:      mergeMetadataItems(provider.DefaultMetadata.Labels, metadata.Labels),
+		Annotations: mergeMetadataItems(provider.DefaultMetadata.Annotations, metadata.Annotations),
+	}
+	if len(merged.Labels) == 0 && len(merged.Annotations) == 0 {
+		return nil
+	}
+	return merged
+}
+
+func mergeMetadataItems(defaults, items []tsuru_client.MetadataItem) []tsuru_client.MetadataItem {
+	byName := map[string]tsuru_client.MetadataItem{}
+	for _, item := range defaults {
+		byName[item.Name] = item
+	}
+	for _, item := range items {
+		byName[item.Name] = item
+	}
+
+	result := []tsuru_client.MetadataItem{}
+	for _, item := range byName {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// withoutDefaultMetadata removes from the metadata read from tsuru the items
+// equal to the default_metadata of the provider, unless they are also
+// configured on the resource.
+func withoutDefaultMetadata(provider *tsuruProvider, metadata tsuru_client.Metadata, configured *tsuru_client.Metadata) tsuru_client.Metadata {
+	if configured == nil {
+		configured = &tsuru_client.Metadata{}
+	}
+
+	return tsuru_client.Metadata{
+		Labels:      withoutDefaultMetadataItems(provider.DefaultMetadata.Labels, metadata.Labels, configured.Labels),
+		Annotations: withoutDefaultMetadataItems(provider.DefaultMetadata.Annotations, metadata.Annotations, configured.Annotations),
+	}
+}
+
+func withoutDefaultMetadataItems(defaults, items, configured []tsuru_client.MetadataItem) []tsuru_client.MetadataItem {
+	result := []tsuru_client.MetadataItem{}
+	for _, item := range items {
+		if containsMetadataItem(defaults, item) && !containsMetadataName(configured, item.Name) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func containsMetadataItem(items []tsuru_client.MetadataItem, item tsuru_client.MetadataItem) bool {
+	for _, i := range items {
+		if i.Name == item.Name && i.Value == item.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMetadataName(items []tsuru_client.MetadataItem, name string) bool {
+	for _, i := range items {
+		if i.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setTagsAll plans tags_all as the tags with the default_tags of the
+// provider, the order of the tags is not a change.
+func setTagsAll(d *schema.ResourceDiff, provider *tsuruProvider) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tags := mergeDefaultTags(provider, parseTags(d.Get("tags")))
+	if add, remove := diffStringSets(parseTags(d.Get("tags_all")), tags); len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	return d.SetNew("tags_all", tags)
+}
+
+// setMetadataAll plans metadata_all as the metadata with the
+// default_metadata of the provider.
+func setMetadataAll(d *schema.ResourceDiff, provider *tsuruProvider) error {
+	if !d.NewValueKnown("metadata") {
+		return d.SetNewComputed("metadata_all")
+	}
+
+	metadata := tsuru_client.Metadata{}
+	if merged := mergeDefaultMetadata(provider, metadataFromResourceData(d.Get("metadata"))); merged != nil {
+		metadata = *merged
+	}
+	all := flattenMetadata(metadata)
+	if reflect.DeepEqual(all, d.Get("metadata_all")) {
+		return nil
+	}
+	return d.SetNew("metadata_all", all)
+}
+
+// metadataUpdate returns the metadata to send on an update, the items of the
+// metadata_all last applied missing on the new metadata, defaults removed
+// from the provider included, are marked as deleted.
+func metadataUpdate(provider *tsuruProvider, d *schema.ResourceData) tsuru_client.Metadata {
+	old, _ := d.GetChange("metadata_all")
+	oldMetadata := metadataFromResourceData(old)
+	if oldMetadata == nil {
+		oldMetadata = &tsuru_client.Metadata{}
+	}
+	newMetadata := mergeDefaultMetadata(provider, metadataFromResourceData(d.Get("metadata")))
+	if newMetadata == nil {
+		newMetadata = &tsuru_client.Metadata{}
+	}
+
+	return tsuru_client.Metadata{
+		Annotations: markRemovedMetadataItemAsDeleted(oldMetadata.Annotations, newMetadata.Annotations),
+		Labels:      markRemovedMetadataItemAsDeleted(oldMetadata.Labels, newMetadata.Labels),
+	}
+}
+
+// setProviderDefaults plans the provider default of the attributes not
+// configured on a new resource, team_owner and owner share default_team_owner.
+// Existing resources keep their values, a pool change could replace them.
+func setProviderDefaults(d *schema.ResourceDiff, provider *tsuruProvider, attributes ...string) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	defaults := map[string]struct {
+		name  string
+		value string
+	}{
+		"team_owner": {"default_team_owner", provider.DefaultTeamOwner},
+		"owner":      {"default_team_owner", provider.DefaultTeamOwner},
+		"pool":       {"default_pool", provider.DefaultPool},
+		"plan":       {"default_plan", provider.DefaultPlan},
+	}
+
+	config := d.GetRawConfig()
+	for _, attribute := range attributes {
+		if config.IsNull() || !config.GetAttr(attribute).IsNull() {
+			continue
+		}
+
+		providerDefault := defaults[attribute]
+		if providerDefault.value == "" {
+			return errors.Errorf("%s is required, set it on the resource or %s on the provider", attribute, providerDefault.name)
+		}
+
+		if err := d.SetNew(attribute, providerDefault.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	tsuru_client "github.com/tsuru/go-tsuruclient/pkg/tsuru"
 )
 
@@ -597,3 +599,55 @@ func TestCheckProcessesListsChangesWhenNewEmpty(t *testing.T) {
 	assert.Equal(t, expectedNew, new)
 	assert.Equal(t, expectedBoth, both)
 }
+
+func TestCheckDeletionProtection(t *testing.T) {
+	provider := &tsuruProvider{ProtectedPools: []string{"prod"}}
+
+	d := schema.TestResourceDataRaw(t, resourceTsuruVolume().Schema, map[string]interface{}{
+		"name":                "vol01",
+		"pool":                "dev",
+		"deletion_protection": true,
+	})
+	diags := checkDeletionProtection(provider, d, "volume", "vol01")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "volume vol01 has deletion protection enabled", diags[0].Summary)
+
+	d = schema.TestResourceDataRaw(t, resourceTsuruJob().Schema, map[string]interface{}{
+		"name": "job01",
+		"pool": "prod",
+	})
+	diags = checkDeletionProtection(provider, d, "job", "job01")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "job job01 is in the protected pool prod", diags[0].Summary)
+
+	d = schema.TestResourceDataRaw(t, resourceTsuruServiceInstance().Schema, map[string]interface{}{
+		"name": "instance01",
+		"pool": "dev",
+	})
+	assert.Nil(t, checkDeletionProtection(provider, d, "service instance", "instance01"))
+}
+
+func TestDefaultMetadata(t *testing.T) {
+	provider := &tsuruProvider{
+		DefaultTags: []string{"team:a"},
+		DefaultMetadata: tsuru_client.Metadata{
+			Labels: []tsuru_client.MetadataItem{{Name: "team", Value: "a"}, {Name: "tier", Value: "web"}},
+		},
+	}
+
+	configured := &tsuru_client.Metadata{
+		Labels: []tsuru_client.MetadataItem{{Name: "tier", Value: "worker"}},
+	}
+
+	merged := mergeDefaultMetadata(provider, configured)
+	assert.Equal(t, []tsuru_client.MetadataItem{{Name: "team", Value: "a"}, {Name: "tier", Value: "worker"}}, merged.Labels)
+
+	read := withoutDefaultMetadata(provider, *merged, configured)
+	assert.Equal(t, []tsuru_client.MetadataItem{{Name: "tier", Value: "worker"}}, read.Labels)
+
+	assert.Nil(t, mergeDefaultMetadata(&tsuruProvider{}, nil))
+
+	assert.Equal(t, []string{"a", "team:a"}, mergeDefaultTags(provider, []string{"a"}))
+	assert.Equal(t, []string{"a"}, withoutDefaultTags(provider, []string{"a", "team:a"}, []string{"a"}))
+	assert.Equal(t, []string{"team:a"}, withoutDefaultTags(provider, []string{"team:a"}, []string{"team:a"}))
+}